@@ -0,0 +1,125 @@
+package chatai
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+
+	"github.com/nirdosh17/go-sdk-template/apierror"
+	"github.com/nirdosh17/go-sdk-template/client"
+	"github.com/nirdosh17/go-sdk-template/model"
+)
+
+// DefaultBatchConcurrency is the number of questions sent in parallel by AskAIBatch unless overridden.
+const DefaultBatchConcurrency = 4
+
+// BatchOptions configures AskAIBatch.
+type BatchOptions struct {
+	// Concurrency is the maximum number of questions sent in parallel. Defaults to DefaultBatchConcurrency.
+	Concurrency int
+	// RetryBudget is the total number of retries shared by all questions of the batch. Defaults to the number of questions.
+	RetryBudget int
+}
+
+// BatchResult holds the outcome of a single question in a batch. Either Answer or Err is set.
+type BatchResult struct {
+	Answer model.AIAnswer
+	Err    *apierror.APIError
+}
+
+// AskAIBatch asks multiple questions using a bounded pool of workers.
+// Results are returned in the same order as inputs.
+//
+// All questions share a single retry budget so that a struggling server is not flooded with retries.
+// Once the context is cancelled, no new questions are sent and the remaining ones fail with the context error.
+// Idempotency key and request ID set on ctx are suffixed with the index of each question, e.g. "order-42-0",
+// so that the server does not answer every question with the stored answer of the first one.
+//
+// Example:
+//
+//	results := ai.AskAIBatch(ctx, questions, chatai.BatchOptions{Concurrency: 8})
+//	for i, r := range results {
+//		if r.Err != nil {
+//			log.Println(questions[i], r.Err)
+//			continue
+//		}
+//		fmt.Println(questions[i], r.Answer.Answer)
+//	}
+func (c *ChatAPI) AskAIBatch(ctx context.Context, inputs []string, opts BatchOptions) []BatchResult {
+	ctx, span := c.startOperation(ctx, "AskAIBatch")
+	defer span.End()
+
+	results := make([]BatchResult, len(inputs))
+
+	workers := opts.Concurrency
+	if workers <= 0 {
+		workers = DefaultBatchConcurrency
+	}
+	if workers > len(inputs) {
+		workers = len(inputs)
+	}
+
+	budget := opts.RetryBudget
+	if budget <= 0 {
+		budget = len(inputs)
+	}
+	ctx = client.WithRetryBudget(ctx, client.NewRetryBudget(budget))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				ans, err := c.AskAIWithContext(itemContext(ctx, i), inputs[i])
+				results[i] = BatchResult{Answer: ans, Err: toAPIError(err)}
+			}
+		}()
+	}
+
+	for i := range inputs {
+		// select picks randomly when both cases are ready, so cancellation is checked first
+		if ctx.Err() == nil {
+			select {
+			case jobs <- i:
+				continue
+			case <-ctx.Done():
+			}
+		}
+		for j := i; j < len(inputs); j++ {
+			results[j].Err = apierror.New(apierror.ErrSDK.ErrCode, ctx.Err())
+		}
+		break
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+// itemContext returns ctx for the i-th question of a batch. Idempotency key and request ID inherited from
+// the batch ctx are suffixed with the index, as a key must only be used for a single call.
+func itemContext(ctx context.Context, i int) context.Context {
+	suffix := "-" + strconv.Itoa(i)
+	if key := client.IdempotencyKeyFromContext(ctx); key != "" {
+		ctx = client.ContextWithIdempotencyKey(ctx, key+suffix)
+	}
+	if id := client.RequestIDFromContext(ctx); id != "" {
+		ctx = client.ContextWithRequestID(ctx, id+suffix)
+	}
+	return ctx
+}
+
+// toAPIError converts errors which are not already APIError, e.g. context errors, into SDK errors.
+func toAPIError(err error) *apierror.APIError {
+	if err == nil {
+		return nil
+	}
+	var apiErr *apierror.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr
+	}
+	return apierror.New(apierror.ErrSDK.ErrCode, err)
+}
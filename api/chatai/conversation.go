@@ -0,0 +1,187 @@
+package chatai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/nirdosh17/go-sdk-template/apierror"
+	"github.com/nirdosh17/go-sdk-template/client"
+	"github.com/nirdosh17/go-sdk-template/model"
+)
+
+// conversationVersion is the version of serialized conversation format.
+const conversationVersion = 1
+
+// Turn is a single question asked in a conversation along with its answer.
+type Turn struct {
+	Question string         `json:"question"`
+	Answer   model.AIAnswer `json:"answer"`
+}
+
+// TruncationPolicy decides which part of the conversation history is sent along with a new question.
+// Returned turns must keep the original order.
+type TruncationPolicy interface {
+	Truncate(history []Turn, question string) []Turn
+}
+
+// TruncationFunc allows a plain function to be used as a TruncationPolicy.
+type TruncationFunc func(history []Turn, question string) []Turn
+
+func (f TruncationFunc) Truncate(history []Turn, question string) []Turn {
+	return f(history, question)
+}
+
+// DropOldest keeps the most recent turns whose combined length along with the new question fits in MaxLength characters.
+type DropOldest struct {
+	MaxLength int
+}
+
+func (p DropOldest) Truncate(history []Turn, question string) []Turn {
+	size := len(question)
+	start := len(history)
+	for i := len(history) - 1; i >= 0; i-- {
+		size += len(history[i].Question) + len(history[i].Answer.Answer)
+		if size > p.MaxLength {
+			break
+		}
+		start = i
+	}
+	return history[start:]
+}
+
+type conversationRequest struct {
+	model.AskRequest
+	ConversationID string `json:"conversationId,omitempty"`
+	History        []Turn `json:"history,omitempty"`
+}
+
+// Conversation keeps the history of a multi-turn chat and sends it as context with each follow-up question.
+//
+// It is safe for concurrent use. Questions are sent one at a time in the order they are asked.
+// Use NewConversation or ResumeConversation to get a conversation bound to the service. A conversation decoded
+// with json.Unmarshal alone has no service to send questions to.
+type Conversation struct {
+	// Truncation limits the history sent with each question. Defaults to DropOldest with MaxInputLength.
+	Truncation TruncationPolicy
+
+	api     *ChatAPI
+	mu      sync.Mutex
+	id      string
+	history []Turn
+}
+
+// NewConversation starts an empty conversation.
+//
+// Example:
+//
+//	conv := ai.NewConversation()
+//	ans, err := conv.Ask(ctx, "what is a goroutine?")
+//	...
+//	ans, err = conv.Ask(ctx, "how is it different from a thread?")
+func (c *ChatAPI) NewConversation() *Conversation {
+	return &Conversation{
+		api:        c,
+		Truncation: DropOldest{MaxLength: MaxInputLength},
+	}
+}
+
+// ResumeConversation restores a conversation previously serialized with json.Marshal.
+// It must be used instead of json.Unmarshal to get a conversation which can ask questions.
+func (c *ChatAPI) ResumeConversation(data []byte) (*Conversation, error) {
+	conv := c.NewConversation()
+	if err := json.Unmarshal(data, conv); err != nil {
+		return nil, apierror.ErrSDK.Record(fmt.Errorf("failed resuming conversation: %w", err))
+	}
+	return conv, nil
+}
+
+// Ask sends the question along with the conversation history and records the answer in the history.
+// It fails with apierror.ErrSDK if the conversation was not created by NewConversation or ResumeConversation.
+func (cv *Conversation) Ask(ctx context.Context, input string) (answer model.AIAnswer, err error) {
+	if cv.api == nil {
+		return answer, apierror.ErrSDK.Record(errors.New("conversation is not bound to a service, use ResumeConversation to restore it"))
+	}
+
+	ctx, span := cv.api.startOperation(ctx, "Conversation.Ask")
+	defer func() { client.EndSpan(span, err) }()
+
+	// blank answer for blank question
+	if input == "" {
+		return answer, nil
+	}
+
+	if err := validateInput(input); err != nil {
+		return answer, err
+	}
+
+	cv.mu.Lock()
+	defer cv.mu.Unlock()
+
+	history := cv.history
+	if cv.Truncation != nil {
+		history = cv.Truncation.Truncate(history, input)
+	}
+	q := conversationRequest{
+		AskRequest:     model.AskRequest{Version: model.AskRequestVersion, Query: input},
+		ConversationID: cv.id,
+		History:        history,
+	}
+
+	if err = cv.api.perform(ctx, "POST", "", q, &answer); err != nil {
+		return answer, err
+	}
+
+	if answer.ConversationID != "" {
+		cv.id = answer.ConversationID
+	}
+	cv.history = append(cv.history, Turn{Question: input, Answer: answer})
+
+	return answer, nil
+}
+
+// ID returns conversation ID assigned by the server. It is empty if the server does not track the conversation.
+func (cv *Conversation) ID() string {
+	cv.mu.Lock()
+	defer cv.mu.Unlock()
+	return cv.id
+}
+
+// History returns a copy of all questions and answers in the conversation.
+func (cv *Conversation) History() []Turn {
+	cv.mu.Lock()
+	defer cv.mu.Unlock()
+	return append([]Turn(nil), cv.history...)
+}
+
+type conversationState struct {
+	Version        int    `json:"version"`
+	ConversationID string `json:"conversationId,omitempty"`
+	History        []Turn `json:"history"`
+}
+
+// MarshalJSON serializes the conversation so that it can be persisted and resumed later with ResumeConversation.
+func (cv *Conversation) MarshalJSON() ([]byte, error) {
+	cv.mu.Lock()
+	defer cv.mu.Unlock()
+	return json.Marshal(conversationState{Version: conversationVersion, ConversationID: cv.id, History: cv.history})
+}
+
+// UnmarshalJSON restores conversation state serialized by MarshalJSON.
+func (cv *Conversation) UnmarshalJSON(b []byte) error {
+	var state conversationState
+	if err := json.Unmarshal(b, &state); err != nil {
+		return err
+	}
+	if state.Version != conversationVersion {
+		return fmt.Errorf("unsupported conversation version %d", state.Version)
+	}
+
+	cv.mu.Lock()
+	defer cv.mu.Unlock()
+	cv.id = state.ConversationID
+	cv.history = state.History
+	return nil
+}
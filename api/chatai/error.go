@@ -10,3 +10,12 @@ var (
 	// ErrInputSizeLimitExceeded represents error where user's input is larger than specified limit.
 	ErrInputSizeLimitExceeded = apierror.New("INPUT_SIZE_EXCEEDED", fmt.Errorf("input size exceeded the limit of %d characters", MaxInputLength))
 )
+
+// validateInput checks the question against the limits of the service.
+func validateInput(input string) error {
+	if len(input) > MaxInputLength {
+		// shared error is copied so that callers never receive the package level value
+		return ErrInputSizeLimitExceeded.Record(ErrInputSizeLimitExceeded.Err)
+	}
+	return nil
+}
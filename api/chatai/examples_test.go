@@ -3,7 +3,10 @@ package chatai_test
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"net/url"
@@ -12,8 +15,13 @@ import (
 
 	"github.com/nirdosh17/go-sdk-template/api/chatai"
 	"github.com/nirdosh17/go-sdk-template/apierror"
+	"github.com/nirdosh17/go-sdk-template/client"
 	"github.com/nirdosh17/go-sdk-template/config"
+	"github.com/nirdosh17/go-sdk-template/logger"
+	"github.com/nirdosh17/go-sdk-template/model"
 	"github.com/nirdosh17/go-sdk-template/test"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 func Example() {
@@ -44,6 +52,29 @@ func ExampleChatAPI_AskAI() {
 	// Answer: reduce heap allocations | Confidence Score: 95
 }
 
+func ExampleChatAPI_Ask() {
+	c := test.HTTPClientFunc(func(r *http.Request) (*http.Response, error) {
+		body, _ := io.ReadAll(r.Body)
+		fmt.Println("request:", string(body))
+		return test.JSONResponse(200, `{"id":"ans-1","answer":"use pprof","confidenceScore":90,"model":"chatai-large","finishReason":"length","usage":{"promptTokens":6,"completionTokens":2,"totalTokens":8},"created":1700000000}`), nil
+	})
+	ai := chatai.NewService(config.NewConfig("apiKey").WithHTTPClient(c))
+
+	ans, err := ai.Ask(context.Background(), model.AskRequest{
+		Query:       "how to profile Go code?",
+		Model:       "chatai-large",
+		Temperature: model.Float64(0.2),
+		MaxTokens:   2,
+	})
+	if err != nil {
+		fmt.Println(err)
+	}
+	fmt.Println(ans.ID, ans.Answer, ans.FinishReason, ans.Usage.TotalTokens, ans.CreatedAt().UTC().Format(time.RFC3339))
+	// Output:
+	// request: {"version":1,"query":"how to profile Go code?","model":"chatai-large","temperature":0.2,"maxTokens":2}
+	// ans-1 use pprof length 8 2023-11-14T22:13:20Z
+}
+
 func ExampleChatAPI_AskAI_inputError() {
 	json := `{"answer":"reduce heap allocations","confidenceScore":95}`
 	c := test.MockHTTPClient{
@@ -63,6 +94,39 @@ func ExampleChatAPI_AskAI_inputError() {
 	// Code: INPUT_SIZE_EXCEEDED | Error: input size exceeded the limit of 200 characters
 }
 
+func ExampleChatAPI_AskAI_configError() {
+	ai := chatai.NewService(config.NewConfig("apiKey").WithEndpoint("api.chatai.com"))
+
+	_, err := ai.AskAI("memory optimization technique in Go")
+
+	var verr *config.ValidationError
+	if errors.Is(err, &apierror.ErrInvalidConfig) && errors.As(err, &verr) {
+		for _, f := range verr.Fields {
+			fmt.Printf("Field: %v | Problem: %v", f.Field, f.Problem)
+		}
+	}
+	// Output:
+	// Field: Endpoint | Problem: URL "api.chatai.com" must use http or https scheme
+}
+
+func ExampleChatAPI_AskAI_serverError() {
+	json := `{"error":{"code":"QUOTA_EXCEEDED","message":"monthly quota exceeded","requestId":"req-123"}}`
+	c := test.MockHTTPClient{
+		JSONBody:   &json,
+		StatusCode: 403,
+	}
+	ai := chatai.NewService(config.NewConfig("apiKey").WithHTTPClient(&c))
+
+	_, err := ai.AskAI("memory optimization technique in Go")
+
+	xe, _ := err.(*apierror.APIError)
+	if xe.ServerCode == "QUOTA_EXCEEDED" {
+		fmt.Printf("Status: %v | Message: %v | Request ID: %v", xe.StatusCode, xe.Message, xe.RequestID)
+	}
+	// Output:
+	// Status: 403 | Message: monthly quota exceeded | Request ID: req-123
+}
+
 func ExampleChatAPI_AskAIWithContext() {
 	json := `{"answer":"in 50 years","confidenceScore":40}`
 	c := test.MockHTTPClient{
@@ -71,7 +135,8 @@ func ExampleChatAPI_AskAIWithContext() {
 	}
 	ai := chatai.NewService(config.NewConfig("apiKey").WithHTTPClient(&c))
 
-	ctx, _ := context.WithTimeout(context.Background(), 20*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
 	ans, err := ai.AskAIWithContext(ctx, "When will the world end?")
 	if err != nil {
 		log.Println(err)
@@ -81,6 +146,112 @@ func ExampleChatAPI_AskAIWithContext() {
 	// Answer: in 50 years | Confidence Score: 40
 }
 
+func ExampleChatAPI_AskAIWithContext_callOptions() {
+	json := `{"answer":"use pprof","confidenceScore":90}`
+	c := test.MockHTTPClient{
+		JSONBody:   &json,
+		StatusCode: 200,
+	}
+	cfg := config.NewConfig("apiKey").WithHTTPClient(&c)
+	ai := chatai.NewService(cfg)
+
+	// options apply to this call only
+	ans, err := ai.AskAIWithContext(context.Background(), "how to profile Go code?",
+		config.Timeout(5*time.Second),
+		config.MaxRetries(1),
+		config.Endpoint("https://eu.chatai.com"),
+		config.Header("X-Team", "search"),
+	)
+	if err != nil {
+		fmt.Println(err)
+	}
+	fmt.Println("Answer:", ans.Answer)
+	fmt.Println("Sent to:", c.LastRequest.URL, "| Team:", c.LastRequest.Header.Get("X-Team"))
+	fmt.Println("Shared config endpoint:", cfg.Endpoint)
+	// Output:
+	// Answer: use pprof
+	// Sent to: https://eu.chatai.com/chatai | Team: search
+	// Shared config endpoint: http://localhost:8000
+}
+
+func ExampleChatAPI_AskAIWithContext_idempotency() {
+	// first attempt is answered by the server but the response is lost
+	var keys []string
+	c := test.HTTPClientFunc(func(r *http.Request) (*http.Response, error) {
+		keys = append(keys, r.Header.Get(client.IdempotencyKeyHeader))
+		if len(keys) == 1 {
+			return test.JSONResponse(502, `{"error":{"message":"bad gateway"}}`), nil
+		}
+		resp := test.JSONResponse(200, `{"answer":"use pprof","confidenceScore":90}`)
+		resp.Header.Set(client.IdempotentReplayedHeader, "true")
+		resp.Header.Set(client.RequestIDHeader, "srv-2")
+		return resp, nil
+	})
+	cfg := config.NewConfig("apiKey").
+		WithHTTPClient(c).
+		WithLogger(logger.LoggerFunc(func(args ...interface{}) {})).
+		WithRetryer(&client.Retry{Delay: time.Millisecond, MaxRetries: 2})
+	ai := chatai.NewService(cfg)
+
+	ctx := client.ContextWithIdempotencyKey(context.Background(), "profiling-question-1")
+	ans, err := ai.AskAIWithContext(ctx, "how to profile Go code?")
+	if err != nil {
+		fmt.Println(err)
+	}
+	fmt.Println(keys)
+	fmt.Println(ans.Answer, "| replayed:", ans.Replayed, "| request ID:", ans.RequestID)
+	// Output:
+	// [profiling-question-1 profiling-question-1]
+	// use pprof | replayed: true | request ID: srv-2
+}
+
+func ExampleChatAPI_AskAIWithContext_requestID() {
+	c := test.HTTPClientFunc(func(r *http.Request) (*http.Response, error) {
+		fmt.Println("sent request ID:", r.Header.Get(client.RequestIDHeader))
+		resp := test.JSONResponse(503, `{"error":{"message":"service unavailable"}}`)
+		resp.Header.Set(client.RequestIDHeader, "srv-7")
+		return resp, nil
+	})
+	ai := chatai.NewService(config.NewConfig("apiKey").WithHTTPClient(c).WithMaxRetries(1))
+
+	// correlate with the request being served
+	ctx := client.ContextWithRequestID(context.Background(), "incoming-42")
+	_, err := ai.AskAIWithContext(ctx, "how to profile Go code?")
+
+	var apiErr *apierror.APIError
+	if errors.As(err, &apiErr) {
+		fmt.Println("server request ID:", apiErr.RequestID)
+	}
+	// Output:
+	// sent request ID: incoming-42
+	// server request ID: srv-7
+}
+
+func ExampleChatAPI_AskAIWithContext_requestIDRetried() {
+	var ids []string
+	c := test.HTTPClientFunc(func(r *http.Request) (*http.Response, error) {
+		ids = append(ids, r.Header.Get(client.RequestIDHeader))
+		if len(ids) == 1 {
+			return test.JSONResponse(503, `{"error":{"message":"service unavailable"}}`), nil
+		}
+		return test.JSONResponse(200, `{"answer":"use pprof","confidenceScore":90}`), nil
+	})
+	cfg := config.NewConfig("apiKey").
+		WithHTTPClient(c).
+		WithLogger(logger.LoggerFunc(func(args ...interface{}) {})).
+		WithRetryer(&client.Retry{Delay: time.Millisecond, MaxRetries: 2})
+	ai := chatai.NewService(cfg)
+
+	// no request ID in ctx, one is generated for the call
+	ans, err := ai.AskAIWithContext(context.Background(), "how to profile Go code?")
+	if err != nil {
+		fmt.Println(err)
+	}
+	fmt.Println("attempts:", len(ids), "| same request ID:", ids[0] != "" && ids[0] == ids[1], "| answer request ID:", ans.RequestID == ids[0])
+	// Output:
+	// attempts: 2 | same request ID: true | answer request ID: true
+}
+
 func ExampleChatAPI_AskAIWithContext_withAdditionalConfigs() {
 	proxyURL, err := url.Parse("https://example.com")
 	if err != nil {
@@ -96,10 +267,369 @@ func ExampleChatAPI_AskAIWithContext_withAdditionalConfigs() {
 		WithMaxRetries(3)
 
 	ai := chatai.NewService(config)
-	ctx, _ := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
 	ans, err := ai.AskAIWithContext(ctx, "When will the world end?")
 	if err != nil {
 		// handle err
 	}
 	fmt.Println("Answer: ", ans.Answer, "Confidence score:", ans.ConfidenceScore)
 }
+
+func ExampleChatAPI_AskAIWithContext_regions() {
+	// eu region is down
+	c := test.HTTPClientFunc(func(r *http.Request) (*http.Response, error) {
+		if r.URL.Host == "eu.chatai.com" {
+			return test.JSONResponse(503, `{"error":{"message":"region unavailable"}}`), nil
+		}
+		return test.JSONResponse(200, `{"answer":"answer from `+r.URL.Host+`","confidenceScore":80}`), nil
+	})
+
+	cfg := config.NewConfig("apiKey").
+		WithHTTPClient(c).
+		WithLogger(logger.LoggerFunc(func(args ...interface{}) { fmt.Println(args...) })).
+		WithRegions(client.EndpointTemplate("https://{region}.chatai.com"), "eu", "us")
+	ai := chatai.NewService(cfg)
+
+	ans, err := ai.AskAIWithContext(context.Background(), "which region answers?")
+	if err != nil {
+		fmt.Println(err)
+	}
+	fmt.Println(ans.Answer)
+	fmt.Println("eu healthy:", cfg.Regions.Healthy("eu"))
+	// Output:
+	// WARN: failing over to next region service=chatai operation=AskAI region=us error=INTERNAL_SERVER_ERROR server response: region unavailable
+	// answer from us.chatai.com
+	// eu healthy: false
+}
+
+func ExampleChatAPI_AskAIWithContext_circuitBreaker() {
+	// server is down
+	c := test.HTTPClientFunc(func(r *http.Request) (*http.Response, error) {
+		return test.JSONResponse(503, `{"error":{"message":"service unavailable"}}`), nil
+	})
+
+	cb := client.NewCircuitBreaker()
+	cb.MinRequests = 2
+	cb.OnStateChange = func(from, to client.CircuitState) {
+		fmt.Println("circuit:", from, "->", to)
+	}
+	cfg := config.NewConfig("apiKey").
+		WithHTTPClient(c).
+		WithMaxRetries(1).
+		WithCircuitBreaker(cb)
+	ai := chatai.NewService(cfg)
+
+	for i := 0; i < 3; i++ {
+		_, err := ai.AskAIWithContext(context.Background(), "are you there?")
+		fmt.Println(err)
+	}
+	// Output:
+	// INTERNAL_SERVER_ERROR server response: service unavailable
+	// circuit: closed -> open
+	// INTERNAL_SERVER_ERROR server response: service unavailable
+	// CIRCUIT_OPEN circuit breaker is open
+}
+
+func ExampleChatAPI_AskAIStream() {
+	body := "data: {\"answer\":\"reduce \"}\n\n" +
+		"data: {\"answer\":\"heap allocations\"}\n\n" +
+		"event: done\ndata: {\"answer\":\"\",\"confidenceScore\":95}\n\n"
+	c := test.MockHTTPClient{
+		JSONBody:   &body,
+		StatusCode: 200,
+	}
+	ai := chatai.NewService(config.NewConfig("apiKey").WithHTTPClient(&c))
+
+	stream, err := ai.AskAIStream(context.Background(), "memory optimization technique in Go")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer stream.Close()
+
+	var answer string
+	for stream.Next() {
+		chunk := stream.Chunk()
+		answer += chunk.Answer
+		if stream.Done() {
+			fmt.Printf("Answer: %v | Confidence Score: %v", answer, chunk.ConfidenceScore)
+		}
+	}
+	if err := stream.Err(); err != nil {
+		fmt.Println(err)
+	}
+	// Output:
+	// Answer: reduce heap allocations | Confidence Score: 95
+}
+
+func ExampleChatAPI_AskAIStream_unknownEvents() {
+	body := "data: {\"answer\":\"use \"}\n\n" +
+		"event: ping\ndata: keep-alive\n\n" +
+		"data: {\"answer\":\"sync.Pool\"}\n\n" +
+		"event: done\ndata: {\"answer\":\"\",\"confidenceScore\":80}\n\n"
+	c := test.MockHTTPClient{
+		JSONBody:   &body,
+		StatusCode: 200,
+	}
+	ai := chatai.NewService(config.NewConfig("apiKey").WithHTTPClient(&c))
+
+	stream, err := ai.AskAIStream(context.Background(), "how to reuse buffers in Go")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer stream.Close()
+
+	var answer string
+	for stream.Next() {
+		answer += stream.Chunk().Answer
+	}
+	fmt.Println("Answer:", answer, "| Done:", stream.Done(), "| Error:", stream.Err())
+	// Output:
+	// Answer: use sync.Pool | Done: true | Error: <nil>
+}
+
+func ExampleChatAPI_SubmitQuestion() {
+	c := test.HTTPClientFunc(func(r *http.Request) (*http.Response, error) {
+		fmt.Println(r.Method, r.URL.Path)
+		if r.Method == "POST" {
+			return test.JSONResponse(202, `{"id":"job-1","status":"queued"}`), nil
+		}
+		return test.JSONResponse(200, `{"id":"job-1","status":"running"}`), nil
+	})
+	ai := chatai.NewService(config.NewConfig("apiKey").WithHTTPClient(c))
+
+	// submit the question and return from the handler right away
+	id, err := ai.SubmitQuestion(context.Background(), model.AskRequest{Query: "summarize the Go memory model"})
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	// check back later
+	job, err := ai.GetJob(context.Background(), id)
+	if err != nil {
+		fmt.Println(err)
+	}
+	fmt.Println(job.ID, job.Status, job.Done())
+	// Output:
+	// POST /chatai/jobs
+	// GET /chatai/jobs/job-1
+	// job-1 running false
+}
+
+func ExampleChatAPI_WaitForJob() {
+	polls := 0
+	c := test.HTTPClientFunc(func(r *http.Request) (*http.Response, error) {
+		polls++
+		if polls < 3 {
+			return test.JSONResponse(200, `{"id":"job-1","status":"running"}`), nil
+		}
+		return test.JSONResponse(200, `{"id":"job-1","status":"succeeded","answer":{"answer":"happens-before","confidenceScore":88}}`), nil
+	})
+	ai := chatai.NewService(config.NewConfig("apiKey").WithHTTPClient(c))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	ans, err := ai.WaitForJob(ctx, "job-1", &client.Waiter{MinDelay: time.Millisecond})
+	if err != nil {
+		fmt.Println(err)
+	}
+	fmt.Println(ans.Answer, "after", polls, "polls")
+	// Output:
+	// happens-before after 3 polls
+}
+
+func ExampleChatAPI_WaitForJob_failed() {
+	c := test.HTTPClientFunc(func(r *http.Request) (*http.Response, error) {
+		return test.JSONResponse(200, `{"id":"job-1","status":"failed","error":{"code":"MODEL_OVERLOADED","message":"try again later"}}`), nil
+	})
+	ai := chatai.NewService(config.NewConfig("apiKey").WithHTTPClient(c))
+
+	_, err := ai.WaitForJob(context.Background(), "job-1", nil)
+
+	var apiErr *apierror.APIError
+	if errors.As(err, &apiErr) {
+		fmt.Println(apiErr.ErrCode, apiErr.ServerCode, apiErr.Message)
+	}
+	fmt.Println(errors.Is(err, chatai.ErrJobFailed))
+	// Output:
+	// JOB_FAILED MODEL_OVERLOADED try again later
+	// true
+}
+
+func ExampleChatAPI_NewConversation() {
+	json := `{"answer":"a lightweight thread managed by Go runtime","confidenceScore":90,"conversationId":"conv-1"}`
+	c := test.MockHTTPClient{
+		JSONBody:   &json,
+		StatusCode: 200,
+	}
+	ai := chatai.NewService(config.NewConfig("apiKey").WithHTTPClient(&c))
+
+	conv := ai.NewConversation()
+	conv.Ask(context.Background(), "what is a goroutine?")
+	conv.Ask(context.Background(), "how is it different from a thread?")
+
+	fmt.Printf("Conversation: %v | Turns: %v", conv.ID(), len(conv.History()))
+	// Output:
+	// Conversation: conv-1 | Turns: 2
+}
+
+func ExampleChatAPI_ResumeConversation() {
+	json := `{"answer":"use sync.Pool","confidenceScore":80,"conversationId":"conv-1"}`
+	c := test.MockHTTPClient{
+		JSONBody:   &json,
+		StatusCode: 200,
+	}
+	ai := chatai.NewService(config.NewConfig("apiKey").WithHTTPClient(&c))
+
+	conv := ai.NewConversation()
+	conv.Ask(context.Background(), "how to reuse objects in Go?")
+
+	// persist the conversation
+	saved, err := conv.MarshalJSON()
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	// and continue later
+	resumed, err := ai.ResumeConversation(saved)
+	if err != nil {
+		fmt.Println(err)
+	}
+	fmt.Printf("Conversation: %v | First question: %v", resumed.ID(), resumed.History()[0].Question)
+	// Output:
+	// Conversation: conv-1 | First question: how to reuse objects in Go?
+}
+
+func ExampleConversation_Ask_unmarshaled() {
+	// conversations decoded with json.Unmarshal are not bound to a service
+	var conv chatai.Conversation
+	if err := json.Unmarshal([]byte(`{"version":1,"conversationId":"conv-1","history":[]}`), &conv); err != nil {
+		fmt.Println(err)
+	}
+
+	_, err := conv.Ask(context.Background(), "how to reuse objects in Go?")
+	fmt.Println(errors.Is(err, &apierror.ErrSDK), conv.ID())
+	// Output:
+	// true conv-1
+}
+
+func ExampleDropOldest() {
+	history := []chatai.Turn{
+		{Question: "what is a goroutine?", Answer: model.AIAnswer{Answer: "a lightweight thread"}},
+		{Question: "what is a channel?", Answer: model.AIAnswer{Answer: "a typed pipe"}},
+	}
+	policy := chatai.DropOldest{MaxLength: 50}
+
+	kept := policy.Truncate(history, "how to close it?")
+	fmt.Printf("Kept: %v | First question: %v", len(kept), kept[0].Question)
+	// Output:
+	// Kept: 1 | First question: what is a channel?
+}
+
+func ExampleChatAPI_AskAIBatch() {
+	json := `{"answer":"use pprof","confidenceScore":85}`
+	c := test.MockHTTPClient{
+		JSONBody:   &json,
+		StatusCode: 200,
+	}
+	ai := chatai.NewService(config.NewConfig("apiKey").WithHTTPClient(&c))
+
+	questions := []string{
+		"how to profile CPU usage in Go?",
+		strings.Repeat("a very large question ", 50),
+	}
+	results := ai.AskAIBatch(context.Background(), questions, chatai.BatchOptions{Concurrency: 2})
+	for _, r := range results {
+		if r.Err != nil {
+			fmt.Println("Error:", r.Err.ErrCode)
+			continue
+		}
+		fmt.Println("Answer:", r.Answer.Answer)
+	}
+	// Output:
+	// Answer: use pprof
+	// Error: INPUT_SIZE_EXCEEDED
+}
+
+func ExampleChatAPI_AskAIBatch_idempotencyKey() {
+	c := test.HTTPClientFunc(func(r *http.Request) (*http.Response, error) {
+		fmt.Println("key:", r.Header.Get(client.IdempotencyKeyHeader), "| request ID:", r.Header.Get(client.RequestIDHeader))
+		return test.JSONResponse(200, `{"answer":"use pprof","confidenceScore":85}`), nil
+	})
+	ai := chatai.NewService(config.NewConfig("apiKey").WithHTTPClient(c))
+
+	ctx := client.ContextWithIdempotencyKey(context.Background(), "report-7")
+	ctx = client.ContextWithRequestID(ctx, "incoming-42")
+	questions := []string{"how to profile CPU usage in Go?", "how to profile memory usage in Go?"}
+	ai.AskAIBatch(ctx, questions, chatai.BatchOptions{Concurrency: 1})
+	// Output:
+	// key: report-7-0 | request ID: incoming-42-0
+	// key: report-7-1 | request ID: incoming-42-1
+}
+
+func ExampleChatAPI_WithInterceptors() {
+	json := `{"answer":"use buffered channels","confidenceScore":70}`
+	c := test.MockHTTPClient{
+		JSONBody:   &json,
+		StatusCode: 200,
+	}
+	auditor := client.InterceptorFuncs{
+		BeforeSendFunc: func(ctx context.Context, req *http.Request) error {
+			req.Header.Set("X-Team", "search")
+			return nil
+		},
+		AfterReceiveFunc: func(ctx context.Context, req *http.Request, resp *http.Response, result interface{}) error {
+			fmt.Println("Audit:", req.Header.Get("X-Team"), resp.StatusCode, result.(*model.AIAnswer).Answer)
+			return nil
+		},
+	}
+	ai := chatai.NewService(config.NewConfig("apiKey").WithHTTPClient(&c)).WithInterceptors(auditor)
+
+	ai.AskAI("how to avoid goroutine blocking?")
+	// Output:
+	// Audit: search 200 use buffered channels
+}
+
+func ExampleChatAPI_AskAIWithContext_tracing() {
+	json := `{"answer":"use context for cancellation","confidenceScore":88}`
+	c := test.MockHTTPClient{
+		JSONBody:   &json,
+		StatusCode: 200,
+	}
+	// use your own provider e.g. otel.GetTracerProvider()
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	ai := chatai.NewService(config.NewConfig("apiKey").WithHTTPClient(&c).WithTracerProvider(tp))
+	ai.AskAIWithContext(context.Background(), "how to stop a goroutine?")
+
+	for _, s := range recorder.Ended() {
+		fmt.Println("Span:", s.Name())
+	}
+	fmt.Println("Trace header sent:", c.LastRequest.Header.Get("traceparent") != "")
+	// Output:
+	// Span: attempt
+	// Span: chatai.AskAI
+	// Trace header sent: true
+}
+
+func ExampleChatAPI_AskAIWithContext_tracingProviderField() {
+	json := `{"answer":"use context for cancellation","confidenceScore":88}`
+	c := test.MockHTTPClient{
+		JSONBody:   &json,
+		StatusCode: 200,
+	}
+	cfg := config.NewConfig("apiKey").WithHTTPClient(&c)
+	// propagator defaults to W3C trace context also when the provider is set directly
+	cfg.TracerProvider = sdktrace.NewTracerProvider()
+
+	ai := chatai.NewService(cfg)
+	ai.AskAIWithContext(context.Background(), "how to stop a goroutine?")
+
+	fmt.Println("Trace header sent:", c.LastRequest.Header.Get("traceparent") != "")
+	// Output:
+	// Trace header sent: true
+}
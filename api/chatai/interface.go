@@ -3,12 +3,21 @@ package chatai
 import (
 	"context"
 
+	"github.com/nirdosh17/go-sdk-template/client"
+	"github.com/nirdosh17/go-sdk-template/config"
+
 	"github.com/nirdosh17/go-sdk-template/model"
 )
 
 // Creating interface so that is can be mocked if needed
 type IChatAI interface {
-	AskAIWithContext(context.Context, string) (model.AIAnswer, error)
+	AskAIWithContext(context.Context, string, ...config.Option) (model.AIAnswer, error)
+	Ask(context.Context, model.AskRequest, ...config.Option) (model.AIAnswer, error)
+	AskAIStream(context.Context, string) (*AnswerStream, error)
+	AskAIBatch(context.Context, []string, BatchOptions) []BatchResult
+	SubmitQuestion(context.Context, model.AskRequest, ...config.Option) (string, error)
+	GetJob(context.Context, string, ...config.Option) (model.Job, error)
+	WaitForJob(context.Context, string, *client.Waiter) (model.AIAnswer, error)
 }
 
 // making sure that ChatAI satisfies this interface
@@ -0,0 +1,102 @@
+package chatai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/nirdosh17/go-sdk-template/apierror"
+	"github.com/nirdosh17/go-sdk-template/client"
+	"github.com/nirdosh17/go-sdk-template/config"
+	"github.com/nirdosh17/go-sdk-template/model"
+)
+
+// ErrJobFailed is returned by WaitForJob when the server fails to answer the question of a job.
+// ServerCode and Message of the error are taken from model.Job.Error.
+var ErrJobFailed = apierror.New("JOB_FAILED", errors.New("job failed"))
+
+// SubmitQuestion submits the question as a job and returns the job ID without waiting for the answer.
+// It suits long questions which would otherwise hold a connection open, e.g. in http handlers which check back later.
+//
+// Example:
+//
+//	id, err := ai.SubmitQuestion(ctx, model.AskRequest{Query: "summarize the Go memory model"})
+//	...
+//	job, err := ai.GetJob(ctx, id)
+//	if job.Status == model.JobSucceeded {
+//		fmt.Println(job.Answer.Answer)
+//	}
+func (c *ChatAPI) SubmitQuestion(ctx context.Context, req model.AskRequest, opts ...config.Option) (jobID string, err error) {
+	api := c.withOptions(opts)
+	ctx, span := api.startOperation(ctx, "SubmitQuestion")
+	defer func() { client.EndSpan(span, err) }()
+
+	if req.Query == "" {
+		return "", apierror.ErrInvalidRequestBody.Record(errors.New("question of a job must not be blank"))
+	}
+	if err := validateInput(req.Query); err != nil {
+		return "", err
+	}
+
+	req.Version = model.AskRequestVersion
+	var job model.Job
+	if err = api.perform(ctx, "POST", "/jobs", req, &job); err != nil {
+		return "", err
+	}
+	if job.ID == "" {
+		return "", apierror.ErrResponseDeserialization.Record(errors.New("job ID missing in response"))
+	}
+	return job.ID, nil
+}
+
+// GetJob returns the current state of the job.
+func (c *ChatAPI) GetJob(ctx context.Context, jobID string, opts ...config.Option) (job model.Job, err error) {
+	api := c.withOptions(opts)
+	ctx, span := api.startOperation(ctx, "GetJob")
+	defer func() { client.EndSpan(span, err) }()
+
+	if jobID == "" {
+		return job, apierror.ErrInvalidRequestBody.Record(errors.New("job ID must not be blank"))
+	}
+
+	err = api.perform(ctx, "GET", "/jobs/"+url.PathEscape(jobID), nil, &job)
+	return job, err
+}
+
+// WaitForJob polls the job with the waiter until it has finished and returns its answer. Default waiter is used if nil.
+// It fails with ErrJobFailed if the job has failed. Waiting is bounded by the context.
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+//	defer cancel()
+//	ans, err := ai.WaitForJob(ctx, id, nil)
+func (c *ChatAPI) WaitForJob(ctx context.Context, jobID string, w *client.Waiter) (model.AIAnswer, error) {
+	if w == nil {
+		w = client.NewWaiter()
+	}
+
+	var job model.Job
+	err := w.Wait(ctx, func(ctx context.Context) (bool, error) {
+		var err error
+		job, err = c.GetJob(ctx, jobID)
+		return job.Done(), err
+	})
+	if err != nil {
+		return model.AIAnswer{}, err
+	}
+
+	if job.Status == model.JobFailed {
+		if job.Error == nil {
+			return model.AIAnswer{}, ErrJobFailed.Record(fmt.Errorf("job %s failed", jobID))
+		}
+		failed := ErrJobFailed.Record(fmt.Errorf("job %s failed: %s", jobID, job.Error.Message))
+		failed.ServerCode, failed.Message = job.Error.Code, job.Error.Message
+		return model.AIAnswer{}, failed
+	}
+	if job.Answer == nil {
+		return model.AIAnswer{}, apierror.ErrResponseDeserialization.Record(fmt.Errorf("answer missing in succeeded job %s", jobID))
+	}
+	return *job.Answer, nil
+}
@@ -2,10 +2,16 @@ package chatai
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/nirdosh17/go-sdk-template/client"
 	"github.com/nirdosh17/go-sdk-template/config"
+	"github.com/nirdosh17/go-sdk-template/logger"
 	"github.com/nirdosh17/go-sdk-template/model"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
 )
 
 const (
@@ -16,6 +22,8 @@ const (
 // ChatAPI exposes APIs related to chatAI service.
 type ChatAPI struct {
 	Config *config.Config
+	// Interceptors overrides interceptors of the config for this service when non-nil. Set an empty slice to disable them.
+	Interceptors []client.Interceptor
 }
 
 // NewService returns an instance of ChatAPI service.
@@ -33,34 +41,176 @@ func NewService(c *config.Config) *ChatAPI {
 	return &ChatAPI{Config: c}
 }
 
-type question struct {
-	query string `json:"query"`
+// AskAIWithContext provides answer for input question from ChatAI service.
+// Options override settings of the config for this call only.
+//
+// Example:
+//
+//	ans, err := ai.AskAIWithContext(ctx, "how to profile Go code?",
+//		config.Timeout(5*time.Second),
+//		config.MaxRetries(1),
+//		config.Header("X-Team", "search"),
+//	)
+func (c *ChatAPI) AskAIWithContext(ctx context.Context, input string, opts ...config.Option) (model.AIAnswer, error) {
+	return c.Ask(ctx, model.AskRequest{Query: input}, opts...)
 }
 
-// AskAIWithContext provides answer for input question from ChatAI service.
-func (c *ChatAPI) AskAIWithContext(ctx context.Context, input string) (model.AIAnswer, error) {
-	var answer model.AIAnswer
+// Ask provides answer for the question of the request, e.g. to choose the model or limit the answer length.
+// Version of the request is set by the service. Options override settings of the config for this call only.
+//
+// Example:
+//
+//	ans, err := ai.Ask(ctx, model.AskRequest{
+//		Query:       "how to profile Go code?",
+//		Model:       "chatai-large",
+//		Temperature: model.Float64(0.2),
+//		MaxTokens:   500,
+//	})
+func (c *ChatAPI) Ask(ctx context.Context, req model.AskRequest, opts ...config.Option) (answer model.AIAnswer, err error) {
+	api := c.withOptions(opts)
+	ctx, span := api.startOperation(ctx, "AskAI")
+	defer func() { client.EndSpan(span, err) }()
 
 	// blank answer for blank question
-	if input == "" {
+	if req.Query == "" {
 		return answer, nil
 	}
 
-	if len(input) > MaxInputLength {
-		return answer, ErrInputSizeLimitExceeded
+	if err := validateInput(req.Query); err != nil {
+		return answer, err
+	}
+
+	req.Version = model.AskRequestVersion
+	err = api.perform(ctx, "POST", "", req, &answer)
+
+	return answer, err
+}
+
+// perform sends "body" to the given path of the service with retries and decodes the response in "target".
+func (c *ChatAPI) perform(ctx context.Context, method string, path string, body interface{}, target interface{}) error {
+	if err := c.Config.Validate(); err != nil {
+		return err
 	}
 
-	// configure HTTP client
-	url := c.Config.Endpoint + "/" + serviceName
+	if c.Config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.Config.Timeout)
+		defer cancel()
+	}
 
-	req := client.Request{Client: c.Config.HTTPClient, Logger: c.Config.Logger, Debug: c.Config.Debug, APIKey: c.Config.APIKey}
-	q := question{query: input}
+	req := c.newRequest()
+	// same key for every attempt so that the server answers a retried question only once
+	ctx = client.EnsureIdempotencyKey(ctx)
+	ctx = client.EnsureRequestID(ctx)
 
-	err := c.Config.Retryer.Run(ctx, func(ctx context.Context) error {
-		return req.Perform(ctx, url, "POST", q, &answer)
+	return c.Config.Retryer.Run(ctx, func(ctx context.Context) error {
+		req.ObserveAttempt(ctx)
+		return c.withEndpoint(ctx, func(ctx context.Context, endpoint string) error {
+			return req.Perform(ctx, endpoint+"/"+serviceName+path, method, body, target)
+		})
 	})
+}
 
-	return answer, err
+// withEndpoint calls fn with the base URL of the service. Regional endpoints of the config are tried in turn until one is available.
+// Calls fail fast while the circuit breaker of the config is open.
+func (c *ChatAPI) withEndpoint(ctx context.Context, fn func(ctx context.Context, endpoint string) error) error {
+	call := func(ctx context.Context) error {
+		if c.Config.Regions == nil {
+			return fn(ctx, c.Config.Endpoint)
+		}
+		return c.Config.Regions.Do(ctx, serviceName, fn)
+	}
+
+	if c.Config.CircuitBreaker == nil {
+		return call(ctx)
+	}
+	return c.Config.CircuitBreaker.Do(ctx, call)
+}
+
+// WithInterceptors overrides interceptors of the config for this service only.
+//
+// Example:
+//
+//	// adds auditor on top of the interceptors of the config
+//	ai := chatai.NewService(c).WithInterceptors(append(c.Interceptors, auditor)...)
+func (c *ChatAPI) WithInterceptors(i ...client.Interceptor) *ChatAPI {
+	c.Interceptors = append([]client.Interceptor{}, i...)
+	return c
+}
+
+// withOptions returns a copy of the service using a copy of the config with given options applied.
+// The service is returned as is if there are no options.
+func (c *ChatAPI) withOptions(opts []config.Option) *ChatAPI {
+	if len(opts) == 0 {
+		return c
+	}
+	return &ChatAPI{Config: c.Config.Copy(opts...), Interceptors: c.Interceptors}
+}
+
+// newRequest returns a requester configured for this service.
+func (c *ChatAPI) newRequest() client.Request {
+	interceptors := c.Config.Interceptors
+	if c.Interceptors != nil {
+		interceptors = c.Interceptors
+	}
+	// added last so that its AfterReceive hook runs first and other interceptors see the complete answer
+	interceptors = append(append([]client.Interceptor{}, interceptors...), answerMetadata)
+
+	// TracerProvider may be set without WithTracerProvider
+	propagator := c.Config.Propagator
+	if propagator == nil && c.Config.TracerProvider != nil {
+		propagator = propagation.TraceContext{}
+	}
+
+	return client.Request{
+		Service:      serviceName,
+		Client:       c.Config.HTTPClient,
+		Logger:       c.Config.Logger,
+		Debug:        c.Config.Debug,
+		APIKey:       c.Config.APIKey,
+		Credentials:  c.Config.Credentials,
+		Headers:      c.Config.Headers,
+		Interceptors: interceptors,
+		Propagator:   propagator,
+		Metrics:      c.Config.Metrics,
+		RateLimiter:  c.Config.RateLimiter,
+		Redaction:    c.Config.Redaction,
+	}
+}
+
+// answerMetadata fills the fields of answers which are read from response headers.
+var answerMetadata = client.InterceptorFuncs{
+	AfterReceiveFunc: func(ctx context.Context, req *http.Request, resp *http.Response, result interface{}) error {
+		if answer, ok := result.(*model.AIAnswer); ok {
+			answer.RequestID = client.RequestID(req, resp)
+			answer.Replayed = client.Replayed(resp)
+		}
+		return nil
+	},
+}
+
+// startOperation prepares the context for the given operation. It carries the logger used by retryers
+// and starts a span if tracing is enabled in the config, which also enables tracing of retry attempts.
+func (c *ChatAPI) startOperation(ctx context.Context, operation string) (context.Context, trace.Span) {
+	if c.Config.Logger != nil {
+		l := c.Config.Logger
+		// warnings of the default logger are only written in debug mode
+		if c.Config.Debug {
+			l = logger.Verbose(l)
+		}
+		ctx = logger.NewContext(ctx, logger.With(l, "service", serviceName, "operation", operation))
+	}
+
+	if c.Config.TracerProvider == nil {
+		return ctx, noop.Span{}
+	}
+
+	tracer := c.Config.TracerProvider.Tracer(client.TracerName)
+	ctx, span := tracer.Start(ctx, serviceName+"."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("sdk.service", serviceName)),
+	)
+	return client.ContextWithTracer(ctx, tracer), span
 }
 
 func (c *ChatAPI) AskAI(question string) (model.AIAnswer, error) {
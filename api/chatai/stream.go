@@ -0,0 +1,145 @@
+package chatai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/nirdosh17/go-sdk-template/apierror"
+	"github.com/nirdosh17/go-sdk-template/client"
+	"github.com/nirdosh17/go-sdk-template/model"
+)
+
+const (
+	// eventMessage carries the next part of the answer. Events without a type are messages too.
+	eventMessage = "message"
+	// eventDone is sent by the server as the last event of a stream. It carries the confidence score.
+	eventDone = "done"
+	// eventError is sent by the server when it fails to generate the answer after the stream has started.
+	eventError = "error"
+)
+
+// AnswerStream iterates over partial answers streamed by ChatAI service.
+//
+// Each chunk contains the next part of the answer. The last chunk carries the confidence score of the full answer.
+type AnswerStream struct {
+	events *client.EventStream
+	chunk  model.AIAnswer
+	done   bool
+	err    error
+}
+
+// Next advances the stream to the next chunk. It returns false when the answer is complete or an error occurs.
+// Events other than answer chunks, e.g. keep-alive pings, are skipped.
+func (s *AnswerStream) Next() bool {
+	if s.done || s.err != nil || s.events == nil {
+		return false
+	}
+
+	for s.events.Next() {
+		ev := s.events.Event()
+
+		switch ev.Event {
+		case "", eventMessage:
+		case eventDone:
+			s.done = true
+		case eventError:
+			s.err = apierror.ErrInternalServer.Record(fmt.Errorf("stream failure: %v", ev.Data))
+			return false
+		default:
+			continue
+		}
+
+		var chunk model.AIAnswer
+		if err := json.Unmarshal([]byte(ev.Data), &chunk); err != nil {
+			s.err = apierror.ErrResponseDeserialization.Record(err)
+			return false
+		}
+		s.chunk = chunk
+		return true
+	}
+
+	if err := s.events.Err(); err != nil {
+		s.err = apierror.ErrSDK.Record(fmt.Errorf("failed reading stream: %w", err))
+	}
+	return false
+}
+
+// Chunk returns the most recent chunk read by Next.
+func (s *AnswerStream) Chunk() model.AIAnswer {
+	return s.chunk
+}
+
+// Done reports whether the final chunk of the answer has been received.
+func (s *AnswerStream) Done() bool {
+	return s.done
+}
+
+// Err returns the error which stopped the stream, if any.
+func (s *AnswerStream) Err() error {
+	return s.err
+}
+
+// Close releases the underlying connection. It is safe to call Close before the stream is fully consumed.
+func (s *AnswerStream) Close() error {
+	if s.events == nil {
+		return nil
+	}
+	return s.events.Close()
+}
+
+// AskAIStream asks ChatAI to stream the answer for input question as it is being generated.
+//
+// Retryer is only applied while establishing the connection. Once the stream has started, failures are reported by AnswerStream.Err.
+//
+// Example:
+//
+//	stream, err := ai.AskAIStream(ctx, "how does Go scheduler work?")
+//	if err != nil {
+//		// handle err
+//	}
+//	defer stream.Close()
+//
+//	for stream.Next() {
+//		fmt.Print(stream.Chunk().Answer)
+//	}
+//	if err := stream.Err(); err != nil {
+//		// handle err
+//	}
+func (c *ChatAPI) AskAIStream(ctx context.Context, input string) (stream *AnswerStream, err error) {
+	ctx, span := c.startOperation(ctx, "AskAIStream")
+	defer func() { client.EndSpan(span, err) }()
+
+	// blank answer for blank question
+	if input == "" {
+		return &AnswerStream{done: true}, nil
+	}
+
+	if err := validateInput(input); err != nil {
+		return nil, err
+	}
+	if err := c.Config.Validate(); err != nil {
+		return nil, err
+	}
+
+	req := c.newRequest()
+	q := model.AskRequest{Version: model.AskRequestVersion, Query: input}
+
+	ctx = client.EnsureIdempotencyKey(ctx)
+	ctx = client.EnsureRequestID(ctx)
+
+	var events *client.EventStream
+	err = c.Config.Retryer.Run(ctx, func(ctx context.Context) error {
+		req.ObserveAttempt(ctx)
+		return c.withEndpoint(ctx, func(ctx context.Context, endpoint string) error {
+			var err error
+			events, err = req.PerformStream(ctx, endpoint+"/"+serviceName+"/stream", "POST", q)
+			return err
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &AnswerStream{events: events}, nil
+}
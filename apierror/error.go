@@ -1,7 +1,11 @@
 // Package apierror contains common api errors returned by the sdk. Errors specific to each services are inside /api/{service}/error.go files.
 package apierror
 
-import "errors"
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
 
 var (
 	// ErrInvalidRequestBody represents error where request parameter are invalid.
@@ -19,34 +23,89 @@ var (
 	// ErrSDK represents local errors which occurred before making call to the server.
 	ErrSDK = APIError{ErrCode: "SDK_ERROR"}
 
+	// ErrCircuitOpen represents error where request is not sent because the circuit breaker is open after repeated server failures.
+	ErrCircuitOpen = APIError{ErrCode: "CIRCUIT_OPEN", Err: errors.New("circuit breaker is open")}
+
+	// ErrInvalidConfig represents error where SDK configuration is invalid or could not be loaded.
+	ErrInvalidConfig = APIError{ErrCode: "INVALID_CONFIG", Err: errors.New("invalid sdk configuration")}
+
+	// ErrCredentials represents error where SDK fails to retrieve the API key from credential providers.
+	ErrCredentials = APIError{ErrCode: "CREDENTIALS_ERROR", Err: errors.New("no valid credentials found")}
+
 	// ErrUnhandled contains errors which are unknown and are not categorized.
 	ErrUnhandled = APIError{ErrCode: "UNHANDLED_ERROR"}
 )
 
+// APIError is the error returned by the sdk. Errors are compared by ErrCode, so a recorded error matches
+// its sentinel with errors.Is e.g. errors.Is(err, &apierror.ErrInternalServer).
+//
+// APIError values must not be modified once returned. Use Record to derive a new error from a sentinel.
 type APIError struct {
 	// ErrCode is unique identifier for each error e.g. INTERNAL_SERVER_ERROR
 	ErrCode string
 
 	// Error is full error object which can be unwrapped
 	Err error
+
+	// StatusCode is the HTTP status code of the server response. Zero for errors which occurred locally.
+	StatusCode int
+
+	// ServerCode is the error code sent by the server in the error response body, if any.
+	ServerCode string
+
+	// Message is the human readable error message sent by the server, if any.
+	Message string
+
+	// RequestID identifies the failed request on the server. Include it while reporting issues.
+	RequestID string
+
+	// Details contains additional error details sent by the server as raw JSON.
+	Details json.RawMessage
+
+	// RetryAfter is the duration server has asked to wait before sending the next request. Zero if not advised.
+	RetryAfter time.Duration
+
+	// RateLimit contains the rate limit state reported by the server, if any.
+	RateLimit *RateLimit
+}
+
+// RateLimit is the rate limit state reported by the server in response headers.
+type RateLimit struct {
+	// Limit is the maximum number of requests allowed in the current window.
+	Limit int
+	// Remaining is the number of requests left in the current window.
+	Remaining int
+	// Reset is the time at which the current window resets.
+	Reset time.Time
 }
 
 func New(code string, err error) *APIError {
-	return &APIError{code, err}
+	return &APIError{ErrCode: code, Err: err}
 }
 
-// Unwrap returns the error object
+// Unwrap returns the underlying error so that errors.Is and errors.As can traverse the error chain.
+func (er *APIError) Unwrap() error {
+	return er.Err
+}
+
+// UnWrap returns the underlying error.
+//
+// Deprecated: use Unwrap.
 func (er *APIError) UnWrap() error {
-	return errors.Unwrap(er.Err)
+	return er.Unwrap()
 }
 
 // Error returns stringified error message. If multiple errors are wrapped, should return all errors as a combined string.
 func (er *APIError) Error() string {
+	if er.Err == nil {
+		return er.ErrCode
+	}
 	// unwrap all errors and convert to string
 	return er.ErrCode + " " + er.Err.Error()
 }
 
-// Record captures the given error object in the custom error type.
+// Record returns a new error of the same type which captures the given error object.
+// The receiver is left untouched, so it is safe to call Record on the shared errors declared in this package.
 //
 //	Example:
 //
@@ -54,8 +113,9 @@ func (er *APIError) Error() string {
 //		err = apierror.ErrInvalidRequestBody.Record(err)
 //	}
 func (er *APIError) Record(err error) *APIError {
-	er.Err = err
-	return er
+	cp := *er
+	cp.Err = err
+	return &cp
 }
 
 // Is checks if the error type is of certain type or not
@@ -0,0 +1,59 @@
+package apierror
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/nirdosh17/go-sdk-template/test"
+)
+
+func TestAPIError_Record(t *testing.T) {
+	cause := errors.New("connection reset")
+	err := ErrSDK.Record(cause)
+
+	test.ExpectEqual(t, "ErrCode", ErrSDK.ErrCode, err.ErrCode)
+	test.ExpectEqual(t, "Err", cause, err.Err)
+	test.ExpectNil(t, "sentinel ErrSDK.Err", ErrSDK.Err)
+	test.ExpectEqual(t, "errors.Is sentinel", true, errors.Is(err, &ErrSDK))
+}
+
+func TestAPIError_Record_concurrent(t *testing.T) {
+	// run with -race to detect shared state between recorded errors
+	const n = 100
+	var wg sync.WaitGroup
+	errs := make([]*APIError, n)
+
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = ErrInvalidRequestBody.Record(fmt.Errorf("cause %d", i))
+		}(i)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		test.ExpectEqual(t, "recorded cause", fmt.Sprintf("cause %d", i), err.Err.Error())
+	}
+	test.ExpectEqual(t, "sentinel cause", "invalid input parameters", ErrInvalidRequestBody.Err.Error())
+}
+
+func TestAPIError_Unwrap(t *testing.T) {
+	err := fmt.Errorf("ask failed: %w", ErrSDK.Record(fmt.Errorf("api request failure: %w", context.DeadlineExceeded)))
+
+	test.ExpectEqual(t, "errors.Is context error", true, errors.Is(err, context.DeadlineExceeded))
+	test.ExpectEqual(t, "errors.Is sentinel", true, errors.Is(err, &ErrSDK))
+	test.ExpectEqual(t, "errors.Is other sentinel", false, errors.Is(err, &ErrInternalServer))
+
+	var apiErr *APIError
+	test.ExpectEqual(t, "errors.As", true, errors.As(err, &apiErr))
+	test.ExpectEqual(t, "ErrCode", "SDK_ERROR", apiErr.ErrCode)
+}
+
+func TestAPIError_Error(t *testing.T) {
+	test.ExpectEqual(t, "Error without cause", "SDK_ERROR", ErrSDK.Record(nil).Error())
+	test.ExpectEqual(t, "Error", "INTERNAL_SERVER_ERROR server failed", ErrInternalServer.Record(ErrInternalServer.Err).Error())
+}
@@ -0,0 +1,187 @@
+package client
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+)
+
+const (
+	// DefaultBaseDelay is the wait time before the first retry of ExponentialRetry.
+	DefaultBaseDelay = 200 * time.Millisecond
+	// DefaultMaxDelay caps the wait time between two retries of ExponentialRetry.
+	DefaultMaxDelay = 20 * time.Second
+	// DefaultMultiplier is the factor by which delay grows after each retry of ExponentialRetry.
+	DefaultMultiplier = 2.0
+)
+
+// Jitter defines how randomness is added to the exponential delay so that multiple clients do not retry in sync.
+type Jitter int
+
+const (
+	// NoJitter uses the exact exponential delay.
+	NoJitter Jitter = iota
+	// FullJitter waits for a random duration between zero and the exponential delay.
+	FullJitter
+	// EqualJitter waits for half of the exponential delay plus a random duration up to the other half.
+	EqualJitter
+	// DecorrelatedJitter waits for a random duration between base delay and three times the previous delay.
+	DecorrelatedJitter
+)
+
+// ExponentialRetry retries the given function with exponentially growing delay between attempts.
+type ExponentialRetry struct {
+	// BaseDelay is the delay before the first retry.
+	BaseDelay time.Duration
+	// Multiplier is the factor by which the delay grows after each retry.
+	Multiplier float64
+	// MaxDelay caps the delay between two attempts, including the wait time advised by the server.
+	MaxDelay time.Duration
+	// Jitter adds randomness to the delay. Defaults to FullJitter in DefaultExponentialRetryer.
+	Jitter Jitter
+	// MaxElapsed caps the total time spent on all attempts and delays. Zero means no limit.
+	MaxElapsed time.Duration
+	// MaxRetries is the number attempts to try running given function.
+	MaxRetries int
+	// Retryable decides which errors are retried. Defaults to IsRetryable.
+	Retryable RetryableFunc
+}
+
+// DefaultExponentialRetryer returns an exponential retryer with full jitter and default settings.
+func DefaultExponentialRetryer() *ExponentialRetry {
+	return &ExponentialRetry{
+		BaseDelay:  DefaultBaseDelay,
+		Multiplier: DefaultMultiplier,
+		MaxDelay:   DefaultMaxDelay,
+		Jitter:     FullJitter,
+		MaxRetries: DefaultMaxRetries,
+	}
+}
+
+// Run executes given function and retries it with exponential backoff until it succeeds,
+// max retries are exhausted, the elapsed time cap is reached or the context is cancelled.
+// If the server has advised a wait time e.g. with Retry-After header, it is used instead of the backoff delay, capped at MaxDelay
+// or DefaultMaxRetryAfter if MaxDelay is not set. The last error is returned without waiting if the advised wait time would
+// exceed the context deadline.
+//
+// Example:
+//
+//	r := &client.ExponentialRetry{
+//		BaseDelay:  100 * time.Millisecond,
+//		Multiplier: 2,
+//		MaxDelay:   5 * time.Second,
+//		Jitter:     client.EqualJitter,
+//		MaxElapsed: 30 * time.Second,
+//		MaxRetries: 5,
+//	}
+//	err := r.Run(ctx, func(ctx context.Context) error {
+//		return doSomething(ctx)
+//	})
+func (r *ExponentialRetry) Run(ctx context.Context, fn func(ctx context.Context) error) error {
+	var (
+		execErr error
+		delay   time.Duration
+		start   = time.Now()
+	)
+	for i := 1; i <= r.MaxRetries; i++ {
+		actx, span := StartAttemptSpan(ctx, i, delay)
+		execErr = fn(actx)
+		EndSpan(span, execErr)
+
+		// no need to wait after the last attempt
+		if execErr == nil || i == r.MaxRetries || !retryable(r.Retryable, execErr) {
+			break
+		}
+
+		delay = r.delay(i, delay)
+		// server advised wait time takes precedence over the backoff
+		if d, ok := retryAfter(execErr); ok {
+			delay = capRetryAfter(d, r.MaxDelay)
+			if exceedsDeadline(ctx, delay) {
+				break
+			}
+		}
+		if r.MaxElapsed > 0 && time.Since(start)+delay > r.MaxElapsed {
+			break
+		}
+		// retries can be limited by a budget shared with other calls
+		if !takeRetry(ctx) {
+			break
+		}
+
+		logRetry(ctx, i, delay, execErr)
+		if err := sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+	return execErr
+}
+
+// SetMaxRetries overrides default max retries but provided value is non-zero.
+func (r *ExponentialRetry) SetMaxRetries(n int) {
+	if n > 0 {
+		r.MaxRetries = n
+	}
+}
+
+// SetRetryable overrides the policy which decides whether an error is retried.
+func (r *ExponentialRetry) SetRetryable(fn RetryableFunc) {
+	r.Retryable = fn
+}
+
+// Clone returns a copy of the retryer.
+func (r *ExponentialRetry) Clone() Retryer {
+	cp := *r
+	return &cp
+}
+
+// delay calculates wait time before the next attempt. "attempt" starts from 1 and "prev" is the last delay used.
+func (r *ExponentialRetry) delay(attempt int, prev time.Duration) time.Duration {
+	multiplier := r.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	exp := float64(r.BaseDelay) * math.Pow(multiplier, float64(attempt-1))
+	d := r.capped(exp)
+
+	switch r.Jitter {
+	case FullJitter:
+		d = randomBetween(0, d)
+	case EqualJitter:
+		d = d/2 + randomBetween(0, d/2)
+	case DecorrelatedJitter:
+		if prev < r.BaseDelay {
+			prev = r.BaseDelay
+		}
+		d = r.capped(float64(randomBetween(r.BaseDelay, prev*3)))
+	}
+	return d
+}
+
+// capped limits the delay to MaxDelay. It also guards against overflow of large exponents.
+func (r *ExponentialRetry) capped(d float64) time.Duration {
+	if r.MaxDelay > 0 && d > float64(r.MaxDelay) {
+		return r.MaxDelay
+	}
+	if d > math.MaxInt64 {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(d)
+}
+
+// randomBetween returns a random duration in [min, max).
+func randomBetween(min, max time.Duration) time.Duration {
+	if max <= min {
+		return min
+	}
+	return min + time.Duration(rand.Int63n(int64(max-min)))
+}
+
+// to enforce compile type check
+var (
+	_ Retryer           = (*ExponentialRetry)(nil)
+	_ RetryPolicySetter = (*ExponentialRetry)(nil)
+	_ RetryerCloner     = (*ExponentialRetry)(nil)
+)
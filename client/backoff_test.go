@@ -0,0 +1,134 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/nirdosh17/go-sdk-template/apierror"
+	"github.com/nirdosh17/go-sdk-template/test"
+)
+
+func TestExponentialRetry_delay(t *testing.T) {
+	base := 100 * time.Millisecond
+	max := time.Second
+
+	t.Run("NoJitter", func(t *testing.T) {
+		r := &ExponentialRetry{BaseDelay: base, Multiplier: 2, MaxDelay: max, Jitter: NoJitter}
+		expected := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second, time.Second}
+		for i, e := range expected {
+			test.ExpectEqual(t, "delay", e, r.delay(i+1, 0))
+		}
+	})
+
+	t.Run("FullJitter", func(t *testing.T) {
+		r := &ExponentialRetry{BaseDelay: base, Multiplier: 2, MaxDelay: max, Jitter: FullJitter}
+		for i := 0; i < 100; i++ {
+			if d := r.delay(3, 0); d < 0 || d >= 400*time.Millisecond {
+				t.Fatalf("expected delay to be in [0, 400ms) but received %v", d)
+			}
+		}
+	})
+
+	t.Run("EqualJitter", func(t *testing.T) {
+		r := &ExponentialRetry{BaseDelay: base, Multiplier: 2, MaxDelay: max, Jitter: EqualJitter}
+		for i := 0; i < 100; i++ {
+			if d := r.delay(3, 0); d < 200*time.Millisecond || d >= 400*time.Millisecond {
+				t.Fatalf("expected delay to be in [200ms, 400ms) but received %v", d)
+			}
+		}
+	})
+
+	t.Run("DecorrelatedJitter", func(t *testing.T) {
+		r := &ExponentialRetry{BaseDelay: base, Multiplier: 2, MaxDelay: max, Jitter: DecorrelatedJitter}
+		prev := time.Duration(0)
+		for i := 1; i <= 100; i++ {
+			d := r.delay(i, prev)
+			lower := base
+			upper := 3 * prev
+			if upper < 3*base {
+				upper = 3 * base
+			}
+			if upper > max {
+				upper = max
+			}
+			if d < lower || d > upper {
+				t.Fatalf("expected delay to be in [%v, %v] but received %v", lower, upper, d)
+			}
+			prev = d
+		}
+	})
+}
+
+func TestExponentialRetry_Run(t *testing.T) {
+	var counter int
+	errfn := func(ctx context.Context) error {
+		counter++
+		return errors.New("I will throw error!")
+	}
+
+	t.Run("MaxRetries", func(t *testing.T) {
+		counter = 0
+		r := &ExponentialRetry{BaseDelay: time.Millisecond, Multiplier: 2, MaxRetries: 4}
+		err := r.Run(context.Background(), errfn)
+		test.ExpectNotNil(t, "execError", err)
+		test.ExpectEqual(t, "function execution counter", 4, counter)
+	})
+
+	t.Run("MaxElapsed", func(t *testing.T) {
+		counter = 0
+		r := &ExponentialRetry{BaseDelay: 50 * time.Millisecond, Multiplier: 2, MaxElapsed: 100 * time.Millisecond, MaxRetries: 10}
+		err := r.Run(context.Background(), errfn)
+		test.ExpectNotNil(t, "execError", err)
+		// waits 50ms before 2nd attempt, next delay of 100ms would cross the cap
+		test.ExpectEqual(t, "function execution counter", 2, counter)
+	})
+
+	t.Run("RetryAfterCapped", func(t *testing.T) {
+		counter = 0
+		r := &ExponentialRetry{BaseDelay: time.Millisecond, Multiplier: 2, MaxDelay: time.Millisecond, MaxRetries: 2}
+		throttled := func(ctx context.Context) error {
+			counter++
+			return &apierror.APIError{ErrCode: apierror.ErrRequestThrottled.ErrCode, Err: apierror.ErrRequestThrottled.Err, RetryAfter: time.Hour}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		err := r.Run(ctx, throttled)
+		test.ExpectEqual(t, "throttled error", true, errors.Is(err, &apierror.ErrRequestThrottled))
+		test.ExpectEqual(t, "function execution counter", 2, counter)
+	})
+
+	t.Run("ContextCancelled", func(t *testing.T) {
+		r := &ExponentialRetry{BaseDelay: time.Second, Multiplier: 2, MaxRetries: 3}
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		err := r.Run(ctx, errfn)
+		test.ExpectEqual(t, "execError", context.DeadlineExceeded, err)
+	})
+}
+
+func Test_DefaultExponentialRetryer(t *testing.T) {
+	expected := ExponentialRetry{
+		BaseDelay:  DefaultBaseDelay,
+		Multiplier: DefaultMultiplier,
+		MaxDelay:   DefaultMaxDelay,
+		Jitter:     FullJitter,
+		MaxRetries: DefaultMaxRetries,
+	}
+	if got := DefaultExponentialRetryer(); !reflect.DeepEqual(got, &expected) {
+		t.Errorf("DefaultExponentialRetryer() = %v, want %v", got, expected)
+	}
+}
+
+func TestExponentialRetry_Clone(t *testing.T) {
+	r := DefaultExponentialRetryer()
+	cp := r.Clone()
+	cp.SetMaxRetries(7)
+
+	test.ExpectEqual(t, "clone MaxRetries", 7, cp.(*ExponentialRetry).MaxRetries)
+	test.ExpectEqual(t, "original MaxRetries", DefaultMaxRetries, r.MaxRetries)
+	test.ExpectEqual(t, "clone Jitter", FullJitter, cp.(*ExponentialRetry).Jitter)
+}
@@ -0,0 +1,303 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/nirdosh17/go-sdk-template/apierror"
+)
+
+const (
+	// DefaultFailureRate opens the circuit when this fraction of requests in the window has failed.
+	DefaultFailureRate = 0.5
+	// DefaultMinRequests is the number of requests in the window needed before the failure rate is considered.
+	DefaultMinRequests = 10
+	// DefaultBreakerWindow is the period over which the failure rate is measured.
+	DefaultBreakerWindow = time.Minute
+	// DefaultBreakerCooldown is the time the circuit stays open before requests are let through again.
+	DefaultBreakerCooldown = 30 * time.Second
+	// DefaultHalfOpenRequests is the number of trial requests which must succeed to close the circuit again.
+	DefaultHalfOpenRequests = 1
+
+	// windowBuckets is the number of buckets the window is divided into.
+	windowBuckets = 10
+)
+
+// CircuitState is the state of a circuit breaker.
+type CircuitState int
+
+const (
+	// CircuitClosed lets all requests through.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen fails all requests fast with apierror.ErrCircuitOpen.
+	CircuitOpen
+	// CircuitHalfOpen lets a limited number of trial requests through to check if the server has recovered.
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// CircuitBreaker stops sending requests to an unavailable server. It opens the circuit when the failure rate
+// within Window exceeds FailureRate, fails fast while open and lets trial requests through after Cooldown.
+// The circuit closes once HalfOpenRequests trial requests succeed, otherwise it opens again.
+//
+// Connection errors and 5xx server errors count as failures by default. It is safe for concurrent use.
+type CircuitBreaker struct {
+	// FailureRate in (0, 1] which opens the circuit. Defaults to DefaultFailureRate.
+	FailureRate float64
+	// MinRequests in the window before the circuit can open. Defaults to DefaultMinRequests.
+	MinRequests int
+	// Window is the period over which the failure rate is measured. Defaults to DefaultBreakerWindow.
+	Window time.Duration
+	// Cooldown is the time the circuit stays open. Defaults to DefaultBreakerCooldown.
+	Cooldown time.Duration
+	// HalfOpenRequests is the number of trial requests in half-open state. Defaults to DefaultHalfOpenRequests.
+	HalfOpenRequests int
+	// IsFailure decides which errors count as failures. Defaults to connection errors and 5xx server errors.
+	IsFailure func(error) bool
+	// OnStateChange is called after the state has changed, e.g. to log or alert. It must not block.
+	OnStateChange func(from, to CircuitState)
+
+	mu        sync.Mutex
+	state     CircuitState
+	openedAt  time.Time
+	buckets   [windowBuckets]breakerBucket
+	trials    int
+	successes int
+	// generation is incremented on every state change to discard outcomes of requests sent in an earlier state.
+	generation uint64
+}
+
+// breakerBucket counts outcomes of requests within a part of the window.
+type breakerBucket struct {
+	start     time.Time
+	successes int
+	failures  int
+}
+
+// NewCircuitBreaker returns a circuit breaker with default settings.
+func NewCircuitBreaker() *CircuitBreaker {
+	return &CircuitBreaker{
+		FailureRate:      DefaultFailureRate,
+		MinRequests:      DefaultMinRequests,
+		Window:           DefaultBreakerWindow,
+		Cooldown:         DefaultBreakerCooldown,
+		HalfOpenRequests: DefaultHalfOpenRequests,
+	}
+}
+
+// State returns the current state of the circuit.
+func (b *CircuitBreaker) State() CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == CircuitOpen && time.Since(b.openedAt) >= b.cooldown() {
+		return CircuitHalfOpen
+	}
+	return b.state
+}
+
+// Do calls fn unless the circuit is open, in which case it fails fast with apierror.ErrCircuitOpen.
+// The error returned by fn is recorded to decide the state of the circuit.
+func (b *CircuitBreaker) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	generation, err := b.allow()
+	if err != nil {
+		return err
+	}
+
+	err = fn(ctx)
+	b.record(generation, err)
+	return err
+}
+
+// Wrap returns a requester whose requests go through the circuit breaker.
+func (b *CircuitBreaker) Wrap(r Requester) Requester {
+	return &breakerRequester{requester: r, breaker: b}
+}
+
+// allow checks whether a request can be sent and returns the generation of the state it is sent in.
+func (b *CircuitBreaker) allow() (uint64, error) {
+	b.mu.Lock()
+	var changed func()
+	defer func() {
+		b.mu.Unlock()
+		if changed != nil {
+			changed()
+		}
+	}()
+
+	if b.state == CircuitOpen {
+		remaining := b.cooldown() - time.Since(b.openedAt)
+		if remaining > 0 {
+			err := apierror.ErrCircuitOpen.Record(apierror.ErrCircuitOpen.Err)
+			err.RetryAfter = remaining
+			return b.generation, err
+		}
+		changed = b.setState(CircuitHalfOpen)
+	}
+
+	if b.state == CircuitHalfOpen {
+		if b.trials >= b.halfOpenRequests() {
+			return b.generation, apierror.ErrCircuitOpen.Record(apierror.ErrCircuitOpen.Err)
+		}
+		b.trials++
+	}
+	return b.generation, nil
+}
+
+// record updates the circuit with the outcome of a request sent in the given generation of the state.
+func (b *CircuitBreaker) record(generation uint64, err error) {
+	failed := err != nil && b.isFailure(err)
+
+	b.mu.Lock()
+	var changed func()
+	defer func() {
+		b.mu.Unlock()
+		if changed != nil {
+			changed()
+		}
+	}()
+
+	// outcome of a request sent before the last state change is stale
+	if generation != b.generation {
+		return
+	}
+
+	switch b.state {
+	case CircuitHalfOpen:
+		// cancelled trial tells nothing about the server, let another request try
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			b.trials--
+			return
+		}
+		if failed {
+			changed = b.setState(CircuitOpen)
+			return
+		}
+		b.successes++
+		if b.successes >= b.halfOpenRequests() {
+			changed = b.setState(CircuitClosed)
+		}
+	case CircuitClosed:
+		successes, failures := b.count(time.Now(), failed)
+		total := successes + failures
+		if failed && total >= b.minRequests() && float64(failures)/float64(total) >= b.failureRate() {
+			changed = b.setState(CircuitOpen)
+		}
+	}
+}
+
+// count adds the outcome to the current bucket and returns the totals within the window. Caller must hold the lock.
+func (b *CircuitBreaker) count(now time.Time, failed bool) (successes, failures int) {
+	// windows shorter than the number of buckets would leave buckets without width
+	width := b.window() / windowBuckets
+	if width <= 0 {
+		width = 1
+	}
+	start := now.Truncate(width)
+	current := &b.buckets[(start.UnixNano()/int64(width))%windowBuckets]
+	if !current.start.Equal(start) {
+		*current = breakerBucket{start: start}
+	}
+	if failed {
+		current.failures++
+	} else {
+		current.successes++
+	}
+
+	for _, bucket := range b.buckets {
+		if now.Sub(bucket.start) < b.window() {
+			successes += bucket.successes
+			failures += bucket.failures
+		}
+	}
+	return successes, failures
+}
+
+// setState moves the circuit to the given state and returns the state change callback to be called after unlocking.
+// Caller must hold the lock.
+func (b *CircuitBreaker) setState(to CircuitState) func() {
+	from := b.state
+	b.state = to
+	b.generation++
+	b.trials, b.successes = 0, 0
+	switch to {
+	case CircuitOpen:
+		b.openedAt = time.Now()
+	case CircuitClosed:
+		b.buckets = [windowBuckets]breakerBucket{}
+	}
+
+	if b.OnStateChange == nil || from == to {
+		return nil
+	}
+	return func() { b.OnStateChange(from, to) }
+}
+
+func (b *CircuitBreaker) isFailure(err error) bool {
+	if b.IsFailure != nil {
+		return b.IsFailure(err)
+	}
+	return isFailover(err)
+}
+
+func (b *CircuitBreaker) failureRate() float64 {
+	if b.FailureRate > 0 {
+		return b.FailureRate
+	}
+	return DefaultFailureRate
+}
+
+func (b *CircuitBreaker) minRequests() int {
+	if b.MinRequests > 0 {
+		return b.MinRequests
+	}
+	return DefaultMinRequests
+}
+
+func (b *CircuitBreaker) window() time.Duration {
+	if b.Window > 0 {
+		return b.Window
+	}
+	return DefaultBreakerWindow
+}
+
+func (b *CircuitBreaker) cooldown() time.Duration {
+	if b.Cooldown > 0 {
+		return b.Cooldown
+	}
+	return DefaultBreakerCooldown
+}
+
+func (b *CircuitBreaker) halfOpenRequests() int {
+	if b.HalfOpenRequests > 0 {
+		return b.HalfOpenRequests
+	}
+	return DefaultHalfOpenRequests
+}
+
+// breakerRequester sends requests of the wrapped requester through a circuit breaker.
+type breakerRequester struct {
+	requester Requester
+	breaker   *CircuitBreaker
+}
+
+func (r *breakerRequester) Perform(ctx context.Context, url string, method string, requestBody interface{}, target interface{}) error {
+	return r.breaker.Do(ctx, func(ctx context.Context) error {
+		return r.requester.Perform(ctx, url, method, requestBody, target)
+	})
+}
+
+// to enforce compile type check
+var _ Requester = (*breakerRequester)(nil)
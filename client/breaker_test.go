@@ -0,0 +1,153 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/nirdosh17/go-sdk-template/apierror"
+	"github.com/nirdosh17/go-sdk-template/test"
+)
+
+var errUnavailable = &apierror.APIError{ErrCode: apierror.ErrInternalServer.ErrCode, StatusCode: 503}
+
+func fail(ctx context.Context) error    { return errUnavailable }
+func succeed(ctx context.Context) error { return nil }
+
+func TestCircuitState_String(t *testing.T) {
+	test.ExpectEqual(t, "closed", "closed", CircuitClosed.String())
+	test.ExpectEqual(t, "open", "open", CircuitOpen.String())
+	test.ExpectEqual(t, "half-open", "half-open", CircuitHalfOpen.String())
+}
+
+func TestCircuitBreaker_opens(t *testing.T) {
+	var changes []string
+	b := NewCircuitBreaker()
+	b.MinRequests = 4
+	b.OnStateChange = func(from, to CircuitState) {
+		changes = append(changes, fmt.Sprintf("%s->%s", from, to))
+	}
+
+	ctx := context.Background()
+	b.Do(ctx, succeed)
+	b.Do(ctx, succeed)
+	b.Do(ctx, fail)
+	test.ExpectEqual(t, "state below min requests", CircuitClosed, b.State())
+
+	b.Do(ctx, fail)
+	test.ExpectEqual(t, "state at failure rate", CircuitOpen, b.State())
+	test.ExpectEqual(t, "state changes", "[closed->open]", fmt.Sprint(changes))
+
+	called := false
+	err := b.Do(ctx, func(ctx context.Context) error {
+		called = true
+		return nil
+	})
+	test.ExpectEqual(t, "called while open", false, called)
+	test.ExpectEqual(t, "circuit open error", true, errors.Is(err, &apierror.ErrCircuitOpen))
+	test.ExpectEqual(t, "retryable", false, IsRetryable(err))
+
+	var apiErr *apierror.APIError
+	errors.As(err, &apiErr)
+	test.ExpectEqual(t, "RetryAfter", true, apiErr.RetryAfter > 0 && apiErr.RetryAfter <= DefaultBreakerCooldown)
+}
+
+func TestCircuitBreaker_ignoresClientErrors(t *testing.T) {
+	b := NewCircuitBreaker()
+	b.MinRequests = 1
+
+	badRequest := apierror.ErrInvalidRequestBody.Record(errors.New("bad question"))
+	for i := 0; i < 5; i++ {
+		b.Do(context.Background(), func(ctx context.Context) error { return badRequest })
+	}
+	test.ExpectEqual(t, "state", CircuitClosed, b.State())
+}
+
+func TestCircuitBreaker_halfOpen(t *testing.T) {
+	var changes []string
+	b := &CircuitBreaker{MinRequests: 1, Cooldown: 10 * time.Millisecond, HalfOpenRequests: 2}
+	b.OnStateChange = func(from, to CircuitState) {
+		changes = append(changes, fmt.Sprintf("%s->%s", from, to))
+	}
+	ctx := context.Background()
+
+	b.Do(ctx, fail)
+	time.Sleep(15 * time.Millisecond)
+	test.ExpectEqual(t, "state after cooldown", CircuitHalfOpen, b.State())
+
+	// trial fails and opens the circuit again
+	test.ExpectEqual(t, "failed trial", error(errUnavailable), b.Do(ctx, fail))
+	test.ExpectEqual(t, "state after failed trial", CircuitOpen, b.State())
+
+	time.Sleep(15 * time.Millisecond)
+	test.ExpectNil(t, "first trial", b.Do(ctx, succeed))
+	test.ExpectEqual(t, "state after first trial", CircuitHalfOpen, b.State())
+	test.ExpectNil(t, "second trial", b.Do(ctx, succeed))
+	test.ExpectEqual(t, "state after second trial", CircuitClosed, b.State())
+
+	test.ExpectEqual(t, "state changes", "[closed->open open->half-open half-open->open open->half-open half-open->closed]", fmt.Sprint(changes))
+}
+
+func TestCircuitBreaker_halfOpen_limitsTrials(t *testing.T) {
+	b := &CircuitBreaker{MinRequests: 1, Cooldown: time.Millisecond}
+	ctx := context.Background()
+	b.Do(ctx, fail)
+	time.Sleep(2 * time.Millisecond)
+
+	err := b.Do(ctx, func(ctx context.Context) error {
+		// second request while the trial is in flight
+		return b.Do(ctx, succeed)
+	})
+	test.ExpectEqual(t, "circuit open error", true, errors.Is(err, &apierror.ErrCircuitOpen))
+}
+
+func TestCircuitBreaker_window(t *testing.T) {
+	b := &CircuitBreaker{MinRequests: 2, Window: 20 * time.Millisecond}
+	ctx := context.Background()
+
+	// failure outside the window is not counted
+	b.Do(ctx, fail)
+	time.Sleep(25 * time.Millisecond)
+	b.Do(ctx, fail)
+	test.ExpectEqual(t, "state", CircuitClosed, b.State())
+}
+
+func TestCircuitBreaker_tinyWindow(t *testing.T) {
+	b := &CircuitBreaker{MinRequests: 2, Window: 5 * time.Nanosecond}
+	ctx := context.Background()
+
+	b.Do(ctx, fail)
+	b.Do(ctx, succeed)
+	test.ExpectEqual(t, "state", CircuitClosed, b.State())
+}
+
+func TestCircuitBreaker_Wrap(t *testing.T) {
+	json := `{"error": {"message": "unavailable"}}`
+	mock := test.MockHTTPClient{StatusCode: 503, JSONBody: &json}
+	b := &CircuitBreaker{MinRequests: 1}
+	r := b.Wrap(&Request{Client: &mock})
+
+	err := r.Perform(context.Background(), "http://api.doesnotmatter.com", "POST", nil, nil)
+	test.ExpectEqual(t, "server error", true, errors.Is(err, &apierror.ErrInternalServer))
+
+	mock.LastRequest = nil
+	err = r.Perform(context.Background(), "http://api.doesnotmatter.com", "POST", nil, nil)
+	test.ExpectEqual(t, "circuit open error", true, errors.Is(err, &apierror.ErrCircuitOpen))
+	test.ExpectEqual(t, "request not sent", true, mock.LastRequest == nil)
+}
+
+func TestCircuitBreaker_halfOpen_cancelledTrial(t *testing.T) {
+	b := &CircuitBreaker{MinRequests: 1, Cooldown: time.Millisecond}
+	b.Do(context.Background(), fail)
+	time.Sleep(2 * time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	b.Do(ctx, func(ctx context.Context) error { return ctx.Err() })
+	test.ExpectEqual(t, "state after cancelled trial", CircuitHalfOpen, b.State())
+
+	test.ExpectNil(t, "next trial", b.Do(context.Background(), succeed))
+	test.ExpectEqual(t, "state after trial", CircuitClosed, b.State())
+}
@@ -0,0 +1,55 @@
+package client
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// RetryBudget limits the total number of retries shared by multiple calls, e.g. all questions of a batch.
+// It is safe for concurrent use.
+type RetryBudget struct {
+	remaining int64
+}
+
+// NewRetryBudget returns a budget which allows n retries in total.
+func NewRetryBudget(n int) *RetryBudget {
+	return &RetryBudget{remaining: int64(n)}
+}
+
+// Take consumes one retry from the budget. It returns false if the budget is exhausted.
+func (b *RetryBudget) Take() bool {
+	for {
+		n := atomic.LoadInt64(&b.remaining)
+		if n <= 0 {
+			return false
+		}
+		if atomic.CompareAndSwapInt64(&b.remaining, n, n-1) {
+			return true
+		}
+	}
+}
+
+// Remaining returns number of retries left in the budget.
+func (b *RetryBudget) Remaining() int {
+	return int(atomic.LoadInt64(&b.remaining))
+}
+
+type retryBudgetKey struct{}
+
+// WithRetryBudget returns a copy of ctx carrying the retry budget. Retryers running with this context stop retrying once the budget is exhausted.
+func WithRetryBudget(ctx context.Context, b *RetryBudget) context.Context {
+	return context.WithValue(ctx, retryBudgetKey{}, b)
+}
+
+// RetryBudgetFromContext returns the retry budget attached to ctx, or nil if there is none.
+// Custom Retryer implementations should consult it before each retry.
+func RetryBudgetFromContext(ctx context.Context) *RetryBudget {
+	b, _ := ctx.Value(retryBudgetKey{}).(*RetryBudget)
+	return b
+}
+
+// takeRetry reports whether a retry is allowed by the budget attached to ctx, if any.
+func takeRetry(ctx context.Context) bool {
+	b := RetryBudgetFromContext(ctx)
+	return b == nil || b.Take()
+}
@@ -0,0 +1,52 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nirdosh17/go-sdk-template/test"
+)
+
+func TestRetryBudget_Take(t *testing.T) {
+	b := NewRetryBudget(50)
+
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		taken int
+	)
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if b.Take() {
+				mu.Lock()
+				taken++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	test.ExpectEqual(t, "taken retries", 50, taken)
+	test.ExpectEqual(t, "RetryBudget.Remaining", 0, b.Remaining())
+}
+
+func TestRetry_Run_withRetryBudget(t *testing.T) {
+	var counter int
+	errfn := func(ctx context.Context) error {
+		counter++
+		return errors.New("I will throw error!")
+	}
+
+	r := &Retry{Delay: time.Millisecond, MaxRetries: 5}
+	ctx := WithRetryBudget(context.Background(), NewRetryBudget(2))
+	err := r.Run(ctx, errfn)
+
+	test.ExpectNotNil(t, "execError", err)
+	// first attempt is free, next two are taken from the budget
+	test.ExpectEqual(t, "function execution counter", 3, counter)
+}
@@ -0,0 +1,45 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/nirdosh17/go-sdk-template/apierror"
+	"github.com/nirdosh17/go-sdk-template/credentials"
+)
+
+// apiKey returns the key sent in the x-api-key header. Credentials provider takes precedence over the static APIKey.
+func (r *Request) apiKey(ctx context.Context) (string, error) {
+	if r.Credentials == nil {
+		return r.APIKey, nil
+	}
+
+	creds, err := r.Credentials.Retrieve(ctx)
+	if err != nil {
+		var apiErr *apierror.APIError
+		if errors.As(err, &apiErr) {
+			return "", err
+		}
+		return "", apierror.ErrCredentials.Record(err)
+	}
+	return creds.APIKey, nil
+}
+
+// renewCredentials discards cached credentials if the server rejected them with 401 Unauthorized.
+// It reports whether the credentials have changed, in which case the request can be sent again with the new key.
+func (r *Request) renewCredentials(ctx context.Context, err error) bool {
+	var apiErr *apierror.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+		return false
+	}
+	cache, ok := r.Credentials.(credentials.Invalidator)
+	if !ok {
+		return false
+	}
+
+	old, oErr := r.Credentials.Retrieve(ctx)
+	cache.Invalidate()
+	renewed, rErr := r.Credentials.Retrieve(ctx)
+	return oErr == nil && rErr == nil && renewed.APIKey != old.APIKey
+}
@@ -0,0 +1,73 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/nirdosh17/go-sdk-template/apierror"
+	"github.com/nirdosh17/go-sdk-template/credentials"
+	"github.com/nirdosh17/go-sdk-template/test"
+)
+
+// keyCheckingClient accepts requests sent with the valid key and rejects others with 401.
+type keyCheckingClient struct {
+	valid string
+	keys  []string
+}
+
+func (c *keyCheckingClient) Do(r *http.Request) (*http.Response, error) {
+	key := r.Header.Get("x-api-key")
+	c.keys = append(c.keys, key)
+
+	status := http.StatusOK
+	if key != c.valid {
+		status = http.StatusUnauthorized
+	}
+	return &http.Response{StatusCode: status, Header: http.Header{}, Body: io.NopCloser(bytes.NewReader([]byte("{}")))}, nil
+}
+
+func TestRequest_Perform_credentials(t *testing.T) {
+	mock := test.MockHTTPClient{StatusCode: 200}
+	r := Request{Client: &mock, APIKey: "static-key", Credentials: credentials.StaticProvider{APIKey: "provided-key"}}
+
+	err := r.Perform(context.Background(), "http://api.doesnotmatter.com", "POST", nil, nil)
+	test.ExpectNil(t, "Request.Perform", err)
+	test.ExpectEqual(t, "x-api-key", "provided-key", mock.LastRequest.Header.Get("x-api-key"))
+}
+
+func TestRequest_Perform_credentialsError(t *testing.T) {
+	mock := test.MockHTTPClient{StatusCode: 200}
+	provider := credentials.ProviderFunc(func(ctx context.Context) (credentials.Credentials, error) {
+		return credentials.Credentials{}, errors.New("unavailable")
+	})
+	r := Request{Client: &mock, Credentials: provider}
+
+	err := r.Perform(context.Background(), "http://api.doesnotmatter.com", "POST", nil, nil)
+	test.ExpectEqual(t, "credentials error", true, errors.Is(err, &apierror.ErrCredentials))
+	test.ExpectEqual(t, "request not sent", true, mock.LastRequest == nil)
+}
+
+func TestRequest_Perform_renewsRejectedCredentials(t *testing.T) {
+	key := "old-key"
+	cache := credentials.NewCache(credentials.ProviderFunc(func(ctx context.Context) (credentials.Credentials, error) {
+		return credentials.Credentials{APIKey: key}, nil
+	}))
+	mock := &keyCheckingClient{valid: "new-key"}
+	r := Request{Client: mock, Credentials: cache}
+
+	err := r.Perform(context.Background(), "http://api.doesnotmatter.com", "POST", nil, nil)
+	test.ExpectEqual(t, "unchanged key rejected", true, errors.Is(err, &apierror.ErrInvalidRequestBody))
+	test.ExpectEqual(t, "requests with unchanged key", 1, len(mock.keys))
+
+	// key is rotated, cached key is used until rejected by the server
+	key = "new-key"
+	err = r.Perform(context.Background(), "http://api.doesnotmatter.com", "POST", nil, nil)
+	test.ExpectNil(t, "Request.Perform", err)
+	test.ExpectEqual(t, "requests", 3, len(mock.keys))
+	test.ExpectEqual(t, "rejected key", "old-key", mock.keys[1])
+	test.ExpectEqual(t, "renewed key", "new-key", mock.keys[2])
+}
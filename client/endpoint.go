@@ -0,0 +1,245 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nirdosh17/go-sdk-template/apierror"
+	"github.com/nirdosh17/go-sdk-template/logger"
+)
+
+const (
+	// DefaultRegionCooldown is the time a failed region is avoided before it is tried again.
+	DefaultRegionCooldown = 30 * time.Second
+
+	// latencyWeight is the weight of the latest sample in the moving average of region latency.
+	latencyWeight = 0.3
+)
+
+// EndpointResolver maps service and region to the base URL of the service.
+type EndpointResolver interface {
+	ResolveEndpoint(service, region string) (string, error)
+}
+
+// EndpointResolverFunc is an adapter to use ordinary functions as EndpointResolver.
+type EndpointResolverFunc func(service, region string) (string, error)
+
+// ResolveEndpoint calls f(service, region).
+func (f EndpointResolverFunc) ResolveEndpoint(service, region string) (string, error) {
+	return f(service, region)
+}
+
+// RegionURLs resolves the same base URL of a region for all services.
+type RegionURLs map[string]string
+
+// ResolveEndpoint returns URL of the region.
+func (m RegionURLs) ResolveEndpoint(service, region string) (string, error) {
+	url, ok := m[region]
+	if !ok {
+		return "", apierror.ErrInvalidConfig.Record(fmt.Errorf("no endpoint for service %q in region %q", service, region))
+	}
+	return url, nil
+}
+
+// EndpointTemplate resolves URL by replacing {service} and {region} placeholders, e.g. "https://{service}.{region}.example.com".
+type EndpointTemplate string
+
+// ResolveEndpoint returns URL of the service in the region.
+func (t EndpointTemplate) ResolveEndpoint(service, region string) (string, error) {
+	return strings.NewReplacer("{service}", service, "{region}", region).Replace(string(t)), nil
+}
+
+// RegionalEndpoints sends requests to the fastest healthy region and fails over to the next region on connection errors
+// and 5xx server errors. It is safe for concurrent use and meant to be shared by all services.
+//
+// Regions are ordered by the moving average of their HTTP round trip times, which exclude waits for the rate limiter,
+// credential renewals and interceptors. Regions without measurements are tried first,
+// in the given order, so that every region is measured once. A failed region is moved to the end of the order until
+// Cooldown expires.
+type RegionalEndpoints struct {
+	Resolver EndpointResolver
+	// Regions in order of preference.
+	Regions []string
+	// Cooldown is the time a failed region is avoided. Defaults to DefaultRegionCooldown if zero.
+	Cooldown time.Duration
+
+	mu     sync.Mutex
+	health map[string]*regionHealth
+}
+
+// roundTrip receives the duration of the HTTP round trip of the last request sent by fn of RegionalEndpoints.Do.
+type roundTrip struct {
+	latency  time.Duration
+	measured bool
+}
+
+type roundTripKey struct{}
+
+// recordRoundTrip reports the duration of an HTTP round trip to RegionalEndpoints.Do running the request, if any.
+func recordRoundTrip(ctx context.Context, d time.Duration) {
+	if rt, ok := ctx.Value(roundTripKey{}).(*roundTrip); ok {
+		rt.latency, rt.measured = d, true
+	}
+}
+
+// regionHealth is the state of a region observed from responses.
+type regionHealth struct {
+	latency  time.Duration
+	measured bool
+	failed   bool
+	failedAt time.Time
+}
+
+// NewRegionalEndpoints returns endpoints of the given regions in order of preference.
+//
+// Example:
+//
+//	endpoints := client.NewRegionalEndpoints(client.EndpointTemplate("https://{region}.api.example.com"), "eu-west", "us-east", "ap-south")
+func NewRegionalEndpoints(resolver EndpointResolver, regions ...string) *RegionalEndpoints {
+	return &RegionalEndpoints{Resolver: resolver, Regions: regions, Cooldown: DefaultRegionCooldown}
+}
+
+// Do calls fn with the base URL of the service in each region until fn succeeds or fails with an error
+// which is not worth a failover. It returns the error of the last call.
+func (e *RegionalEndpoints) Do(ctx context.Context, service string, fn func(ctx context.Context, endpoint string) error) error {
+	var err error
+	for i, region := range e.order() {
+		if i > 0 {
+			logger.FromContext(ctx).LogLevel(logger.SeverityWarn, "failing over to next region", "region", region, "error", err)
+		}
+
+		url, rErr := e.Resolver.ResolveEndpoint(service, region)
+		if rErr != nil {
+			return rErr
+		}
+
+		start := time.Now()
+		rt := &roundTrip{}
+		err = fn(context.WithValue(ctx, roundTripKey{}, rt), url)
+		latency := rt.latency
+		// fn which does not send requests with Request is measured as a whole
+		if !rt.measured {
+			latency = time.Since(start)
+		}
+		e.report(region, latency, err)
+
+		if err == nil || !isFailover(err) || ctx.Err() != nil {
+			return err
+		}
+	}
+	if err == nil {
+		err = apierror.ErrInvalidConfig.Record(errors.New("no regions configured"))
+	}
+	return err
+}
+
+// Healthy reports whether the region has not failed within the cooldown period.
+func (e *RegionalEndpoints) Healthy(region string) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.healthy(e.health[region], time.Now())
+}
+
+// Latency returns the moving average of response times of the region. Zero if it has not been measured yet.
+func (e *RegionalEndpoints) Latency(region string) time.Duration {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if h := e.health[region]; h != nil {
+		return h.latency
+	}
+	return 0
+}
+
+// order returns regions in the order they should be tried.
+func (e *RegionalEndpoints) order() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	now := time.Now()
+	regions := append([]string{}, e.Regions...)
+	rank := func(region string) (int, time.Duration) {
+		h := e.health[region]
+		switch {
+		case !e.healthy(h, now):
+			return 2, 0
+		case h == nil || !h.measured:
+			return 0, 0
+		default:
+			return 1, h.latency
+		}
+	}
+
+	sort.SliceStable(regions, func(i, j int) bool {
+		ri, li := rank(regions[i])
+		rj, lj := rank(regions[j])
+		if ri != rj {
+			return ri < rj
+		}
+		return li < lj
+	})
+	return regions
+}
+
+// report records the outcome of a call to the region.
+func (e *RegionalEndpoints) report(region string, latency time.Duration, err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.health == nil {
+		e.health = map[string]*regionHealth{}
+	}
+	h := e.health[region]
+	if h == nil {
+		h = &regionHealth{}
+		e.health[region] = h
+	}
+
+	if err != nil && isFailover(err) {
+		h.failed, h.failedAt = true, time.Now()
+		return
+	}
+
+	h.failed = false
+	if !h.measured {
+		h.latency, h.measured = latency, true
+		return
+	}
+	h.latency = time.Duration(latencyWeight*float64(latency) + (1-latencyWeight)*float64(h.latency))
+}
+
+func (e *RegionalEndpoints) healthy(h *regionHealth, now time.Time) bool {
+	if h == nil || !h.failed {
+		return true
+	}
+	cooldown := e.Cooldown
+	if cooldown == 0 {
+		cooldown = DefaultRegionCooldown
+	}
+	return now.Sub(h.failedAt) >= cooldown
+}
+
+// isFailover reports whether the error indicates that the region is unavailable, i.e. a connection failure or 5xx server error.
+func isFailover(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var apiErr *apierror.APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	switch {
+	case apiErr.StatusCode >= http.StatusInternalServerError:
+		return true
+	case apiErr.ErrCode == apierror.ErrSDK.ErrCode && apiErr.StatusCode == 0:
+		// request could not be sent
+		return true
+	}
+	return false
+}
@@ -0,0 +1,128 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nirdosh17/go-sdk-template/apierror"
+	"github.com/nirdosh17/go-sdk-template/logger"
+	"github.com/nirdosh17/go-sdk-template/test"
+)
+
+func TestEndpointResolvers(t *testing.T) {
+	url, err := EndpointTemplate("https://{service}.{region}.example.com").ResolveEndpoint("chatai", "eu")
+	test.ExpectNil(t, "EndpointTemplate", err)
+	test.ExpectEqual(t, "template URL", "https://chatai.eu.example.com", url)
+
+	urls := RegionURLs{"eu": "https://eu.example.com"}
+	url, err = urls.ResolveEndpoint("chatai", "eu")
+	test.ExpectNil(t, "RegionURLs", err)
+	test.ExpectEqual(t, "region URL", "https://eu.example.com", url)
+
+	_, err = urls.ResolveEndpoint("chatai", "us")
+	test.ExpectEqual(t, "unknown region", true, errors.Is(err, &apierror.ErrInvalidConfig))
+}
+
+func TestRegionalEndpoints_Do_failover(t *testing.T) {
+	e := NewRegionalEndpoints(EndpointTemplate("https://{region}.example.com"), "eu", "us", "ap")
+	serverErr := &apierror.APIError{ErrCode: apierror.ErrInternalServer.ErrCode, StatusCode: 503}
+	connErr := apierror.ErrSDK.Record(errors.New("api request failure: connection refused"))
+
+	var called []string
+	err := e.Do(context.Background(), "chatai", func(ctx context.Context, endpoint string) error {
+		called = append(called, endpoint)
+		switch endpoint {
+		case "https://eu.example.com":
+			return serverErr
+		case "https://us.example.com":
+			return connErr
+		}
+		return nil
+	})
+	test.ExpectNil(t, "Do", err)
+	test.ExpectEqual(t, "called endpoints", "https://eu.example.com,https://us.example.com,https://ap.example.com", strings.Join(called, ","))
+	test.ExpectEqual(t, "eu healthy", false, e.Healthy("eu"))
+	test.ExpectEqual(t, "us healthy", false, e.Healthy("us"))
+	test.ExpectEqual(t, "ap healthy", true, e.Healthy("ap"))
+
+	// failed regions are tried last
+	test.ExpectEqual(t, "order", "ap,eu,us", strings.Join(e.order(), ","))
+}
+
+func TestRegionalEndpoints_Do_noFailover(t *testing.T) {
+	e := NewRegionalEndpoints(EndpointTemplate("https://{region}.example.com"), "eu", "us")
+	errs := []error{
+		apierror.ErrInvalidRequestBody.Record(errors.New("bad question")),
+		&apierror.APIError{ErrCode: apierror.ErrRequestThrottled.ErrCode, StatusCode: 429},
+		apierror.ErrSDK.Record(context.Canceled),
+	}
+
+	for _, want := range errs {
+		calls := 0
+		err := e.Do(context.Background(), "chatai", func(ctx context.Context, endpoint string) error {
+			calls++
+			return want
+		})
+		test.ExpectEqual(t, "error", want, err)
+		test.ExpectEqual(t, "calls for "+want.Error(), 1, calls)
+	}
+	test.ExpectEqual(t, "eu healthy", true, e.Healthy("eu"))
+}
+
+func TestRegionalEndpoints_Do_allRegionsFail(t *testing.T) {
+	e := NewRegionalEndpoints(EndpointTemplate("https://{region}.example.com"), "eu", "us")
+	serverErr := &apierror.APIError{ErrCode: apierror.ErrInternalServer.ErrCode, StatusCode: 500}
+
+	calls := 0
+	err := e.Do(context.Background(), "chatai", func(ctx context.Context, endpoint string) error {
+		calls++
+		return serverErr
+	})
+	test.ExpectEqual(t, "error", error(serverErr), err)
+	test.ExpectEqual(t, "calls", 2, calls)
+}
+
+func TestRegionalEndpoints_Do_roundTripLatency(t *testing.T) {
+	e := NewRegionalEndpoints(EndpointTemplate("https://{region}.example.com"), "eu")
+	json := `{}`
+	r := Request{Client: &test.MockHTTPClient{StatusCode: 200, JSONBody: &json}, Logger: logger.NewDefaultLogger()}
+
+	err := e.Do(context.Background(), "chatai", func(ctx context.Context, endpoint string) error {
+		// e.g. waiting for the rate limiter is not counted against the region
+		time.Sleep(50 * time.Millisecond)
+		return r.Perform(ctx, endpoint, "GET", nil, nil)
+	})
+	test.ExpectNil(t, "Do", err)
+	if l := e.Latency("eu"); l >= 50*time.Millisecond {
+		t.Errorf("expected latency of the round trip only but got %v", l)
+	}
+}
+
+func TestRegionalEndpoints_order(t *testing.T) {
+	e := NewRegionalEndpoints(EndpointTemplate("https://{region}.example.com"), "eu", "us", "ap", "sa")
+	e.report("eu", 300*time.Millisecond, nil)
+	e.report("us", 100*time.Millisecond, nil)
+	e.report("ap", 200*time.Millisecond, nil)
+
+	// unmeasured regions first, then by latency
+	test.ExpectEqual(t, "order", "sa,us,ap,eu", strings.Join(e.order(), ","))
+
+	e.report("us", 1000*time.Millisecond, nil)
+	test.ExpectEqual(t, "moving average", 370*time.Millisecond, e.Latency("us"))
+	e.report("sa", 400*time.Millisecond, nil)
+	test.ExpectEqual(t, "order after slow response", "ap,eu,us,sa", strings.Join(e.order(), ","))
+}
+
+func TestRegionalEndpoints_cooldown(t *testing.T) {
+	e := NewRegionalEndpoints(EndpointTemplate("https://{region}.example.com"), "eu", "us")
+	e.Cooldown = 10 * time.Millisecond
+	e.report("eu", 0, &apierror.APIError{ErrCode: apierror.ErrInternalServer.ErrCode, StatusCode: 502})
+	test.ExpectEqual(t, "order during cooldown", "us,eu", strings.Join(e.order(), ","))
+
+	time.Sleep(15 * time.Millisecond)
+	test.ExpectEqual(t, "healthy after cooldown", true, e.Healthy("eu"))
+	test.ExpectEqual(t, "order after cooldown", "eu,us", strings.Join(e.order(), ","))
+}
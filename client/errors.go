@@ -0,0 +1,62 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/nirdosh17/go-sdk-template/apierror"
+)
+
+// errorBody is the error response sent by the server. Fields can either be at the top level or nested under "error".
+type errorBody struct {
+	Code      string          `json:"code"`
+	Message   string          `json:"message"`
+	RequestID string          `json:"requestId"`
+	Details   json.RawMessage `json:"details"`
+	Error     *errorBody      `json:"error"`
+}
+
+// serverError converts 4XX and 5XX responses into APIError. The error body is decoded when it is valid JSON.
+func serverError(status int, h http.Header, body []byte) *apierror.APIError {
+	var base *apierror.APIError
+	switch {
+	case status == http.StatusTooManyRequests:
+		base = &apierror.ErrRequestThrottled
+	case status >= 500:
+		base = &apierror.ErrInternalServer
+	default:
+		base = &apierror.ErrInvalidRequestBody
+	}
+
+	apiErr := &apierror.APIError{ErrCode: base.ErrCode, Err: base.Err, StatusCode: status}
+
+	var eb errorBody
+	if err := json.Unmarshal(body, &eb); err == nil {
+		if eb.Error != nil {
+			eb = *eb.Error
+		}
+		apiErr.ServerCode = eb.Code
+		apiErr.Message = eb.Message
+		apiErr.RequestID = eb.RequestID
+		apiErr.Details = eb.Details
+	}
+
+	switch {
+	case apiErr.Message != "":
+		apiErr.Err = fmt.Errorf("server response: %v", apiErr.Message)
+	case len(body) > 0:
+		apiErr.Err = fmt.Errorf("server response: %v", string(body))
+	case apiErr.Err == nil:
+		apiErr.Err = errors.New(http.StatusText(status))
+	}
+
+	if status == http.StatusTooManyRequests {
+		apiErr.RetryAfter, _ = parseRetryAfter(h.Get(headerRetryAfter), time.Now())
+		apiErr.RateLimit = parseRateLimit(h)
+	}
+
+	return apiErr
+}
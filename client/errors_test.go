@@ -0,0 +1,42 @@
+package client
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/nirdosh17/go-sdk-template/test"
+)
+
+func Test_serverError(t *testing.T) {
+	t.Run("nested error body", func(t *testing.T) {
+		body := `{"error": {"code": "QUOTA_EXCEEDED", "message": "monthly quota exceeded", "requestId": "req-123", "details": {"quota": 1000}}}`
+		err := serverError(403, http.Header{}, []byte(body))
+		test.ExpectEqual(t, "ErrCode", "INVALID_REQUEST_BODY", err.ErrCode)
+		test.ExpectEqual(t, "StatusCode", 403, err.StatusCode)
+		test.ExpectEqual(t, "ServerCode", "QUOTA_EXCEEDED", err.ServerCode)
+		test.ExpectEqual(t, "Message", "monthly quota exceeded", err.Message)
+		test.ExpectEqual(t, "RequestID", "req-123", err.RequestID)
+		test.ExpectEqual(t, "Details", `{"quota": 1000}`, string(err.Details))
+		test.ExpectEqual(t, "Error", "INVALID_REQUEST_BODY server response: monthly quota exceeded", err.Error())
+	})
+
+	t.Run("flat error body", func(t *testing.T) {
+		body := `{"code": "MODEL_UNAVAILABLE", "message": "model is overloaded", "requestId": "req-456"}`
+		err := serverError(503, http.Header{}, []byte(body))
+		test.ExpectEqual(t, "ErrCode", "INTERNAL_SERVER_ERROR", err.ErrCode)
+		test.ExpectEqual(t, "StatusCode", 503, err.StatusCode)
+		test.ExpectEqual(t, "ServerCode", "MODEL_UNAVAILABLE", err.ServerCode)
+		test.ExpectEqual(t, "RequestID", "req-456", err.RequestID)
+	})
+
+	t.Run("non JSON body", func(t *testing.T) {
+		err := serverError(400, http.Header{}, []byte("bad request"))
+		test.ExpectEqual(t, "ServerCode", "", err.ServerCode)
+		test.ExpectEqual(t, "Error", "INVALID_REQUEST_BODY server response: bad request", err.Error())
+	})
+
+	t.Run("empty body", func(t *testing.T) {
+		err := serverError(500, http.Header{}, nil)
+		test.ExpectEqual(t, "Error", "INTERNAL_SERVER_ERROR server failed", err.Error())
+	})
+}
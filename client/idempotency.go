@@ -0,0 +1,82 @@
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+const (
+	// IdempotencyKeyHeader carries the key which lets the server recognize retries of a request it has already processed.
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader is set to true by the server when it returns the stored response of an earlier request with the same key.
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+)
+
+type idempotencyKey struct{}
+
+// ContextWithIdempotencyKey returns a copy of ctx carrying the idempotency key sent with POST and PATCH requests.
+// Services generate a key for every call, so it is only needed to choose the key, e.g. to tie it to an order ID
+// so that the call is not repeated even across restarts of the application. A key must only be used for a single call.
+//
+// Example:
+//
+//	ctx = client.ContextWithIdempotencyKey(ctx, "order-42")
+//	ans, err := ai.AskAIWithContext(ctx, "summarize order 42")
+func ContextWithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKey{}, key)
+}
+
+// IdempotencyKeyFromContext returns the idempotency key attached to ctx, or an empty string if there is none.
+func IdempotencyKeyFromContext(ctx context.Context) string {
+	key, _ := ctx.Value(idempotencyKey{}).(string)
+	return key
+}
+
+// EnsureIdempotencyKey returns ctx as is if it carries an idempotency key, otherwise a copy of ctx carrying a new key.
+// Services call it once per call before running the retryer, so that all attempts are sent with the same key.
+func EnsureIdempotencyKey(ctx context.Context) context.Context {
+	if IdempotencyKeyFromContext(ctx) != "" {
+		return ctx
+	}
+	return ContextWithIdempotencyKey(ctx, NewIdempotencyKey())
+}
+
+// NewIdempotencyKey returns a random version 4 UUID.
+func NewIdempotencyKey() string {
+	return newUUID()
+}
+
+// newUUID returns a random version 4 UUID.
+func newUUID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		// crypto/rand does not fail on supported platforms
+		panic(fmt.Sprintf("failed generating uuid: %v", err))
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// Replayed reports whether the server returned the stored response of an earlier request with the same idempotency key.
+func Replayed(resp *http.Response) bool {
+	if resp == nil {
+		return false
+	}
+	replayed, _ := strconv.ParseBool(resp.Header.Get(IdempotentReplayedHeader))
+	return replayed
+}
+
+// setIdempotencyKey adds the idempotency key of ctx to POST and PATCH requests unless the header is already set.
+func setIdempotencyKey(ctx context.Context, req *http.Request) {
+	if req.Method != http.MethodPost && req.Method != http.MethodPatch {
+		return
+	}
+	key := IdempotencyKeyFromContext(ctx)
+	if key != "" && req.Header.Get(IdempotencyKeyHeader) == "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+}
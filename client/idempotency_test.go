@@ -0,0 +1,54 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/nirdosh17/go-sdk-template/test"
+)
+
+func TestNewIdempotencyKey(t *testing.T) {
+	key := NewIdempotencyKey()
+	uuid := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	test.ExpectEqual(t, "UUID v4 "+key, true, uuid.MatchString(key))
+	test.ExpectEqual(t, "unique", true, key != NewIdempotencyKey())
+}
+
+func TestEnsureIdempotencyKey(t *testing.T) {
+	ctx := EnsureIdempotencyKey(context.Background())
+	key := IdempotencyKeyFromContext(ctx)
+	test.ExpectEqual(t, "generated", true, key != "")
+	test.ExpectEqual(t, "kept", key, IdempotencyKeyFromContext(EnsureIdempotencyKey(ctx)))
+
+	ctx = EnsureIdempotencyKey(ContextWithIdempotencyKey(context.Background(), "order-42"))
+	test.ExpectEqual(t, "custom", "order-42", IdempotencyKeyFromContext(ctx))
+}
+
+func TestRequest_Perform_idempotencyKey(t *testing.T) {
+	json := `{"answer": "yes"}`
+	mock := test.MockHTTPClient{StatusCode: 200, JSONBody: &json}
+	r := Request{Client: &mock}
+	ctx := ContextWithIdempotencyKey(context.Background(), "order-42")
+
+	r.Perform(ctx, "http://api.doesnotmatter.com", "POST", nil, nil)
+	test.ExpectEqual(t, "POST", "order-42", mock.LastRequest.Header.Get(IdempotencyKeyHeader))
+
+	r.Perform(ctx, "http://api.doesnotmatter.com", "GET", nil, nil)
+	test.ExpectEqual(t, "GET", "", mock.LastRequest.Header.Get(IdempotencyKeyHeader))
+
+	r.Perform(context.Background(), "http://api.doesnotmatter.com", "POST", nil, nil)
+	test.ExpectEqual(t, "without key", "", mock.LastRequest.Header.Get(IdempotencyKeyHeader))
+
+	r.Headers = http.Header{IdempotencyKeyHeader: {"explicit"}}
+	r.Perform(ctx, "http://api.doesnotmatter.com", "POST", nil, nil)
+	test.ExpectEqual(t, "explicit header", "explicit", mock.LastRequest.Header.Get(IdempotencyKeyHeader))
+}
+
+func TestReplayed(t *testing.T) {
+	test.ExpectEqual(t, "nil response", false, Replayed(nil))
+	test.ExpectEqual(t, "no header", false, Replayed(&http.Response{Header: http.Header{}}))
+	test.ExpectEqual(t, "replayed", true, Replayed(&http.Response{Header: http.Header{IdempotentReplayedHeader: {"true"}}}))
+	test.ExpectEqual(t, "invalid", false, Replayed(&http.Response{Header: http.Header{IdempotentReplayedHeader: {"maybe"}}}))
+}
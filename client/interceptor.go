@@ -0,0 +1,92 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// Interceptor hooks into every request performed by Request, e.g. to add headers, audit log or collect metrics.
+//
+// Interceptors are chained like middlewares. BeforeSend is called in the order interceptors are registered
+// whereas AfterReceive and OnError are called in reverse order, so the first interceptor sees the final outcome.
+type Interceptor interface {
+	// BeforeSend is called before the request is sent and can mutate it. Returning an error aborts the request.
+	// The error is passed to OnError and returned to the caller, wrap it with MarkPermanent to avoid retries.
+	BeforeSend(ctx context.Context, req *http.Request) error
+	// AfterReceive is called after a successful response. "result" is the decoded response i.e. the target passed to Perform
+	// or the *EventStream returned by PerformStream. Response body has already been consumed.
+	// Returning an error fails the request.
+	AfterReceive(ctx context.Context, req *http.Request, resp *http.Response, result interface{}) error
+	// OnError is called when the request fails. The returned error replaces the original one.
+	OnError(ctx context.Context, req *http.Request, err error) error
+}
+
+// InterceptorFuncs allows to implement only the required hooks of an Interceptor. Nil functions are skipped.
+//
+// Example:
+//
+//	auditor := client.InterceptorFuncs{
+//		BeforeSendFunc: func(ctx context.Context, req *http.Request) error {
+//			req.Header.Set("X-Team", "search")
+//			return nil
+//		},
+//		OnErrorFunc: func(ctx context.Context, req *http.Request, err error) error {
+//			log.Println("request failed", req.URL, err)
+//			return err
+//		},
+//	}
+type InterceptorFuncs struct {
+	BeforeSendFunc   func(ctx context.Context, req *http.Request) error
+	AfterReceiveFunc func(ctx context.Context, req *http.Request, resp *http.Response, result interface{}) error
+	OnErrorFunc      func(ctx context.Context, req *http.Request, err error) error
+}
+
+func (f InterceptorFuncs) BeforeSend(ctx context.Context, req *http.Request) error {
+	if f.BeforeSendFunc == nil {
+		return nil
+	}
+	return f.BeforeSendFunc(ctx, req)
+}
+
+func (f InterceptorFuncs) AfterReceive(ctx context.Context, req *http.Request, resp *http.Response, result interface{}) error {
+	if f.AfterReceiveFunc == nil {
+		return nil
+	}
+	return f.AfterReceiveFunc(ctx, req, resp, result)
+}
+
+func (f InterceptorFuncs) OnError(ctx context.Context, req *http.Request, err error) error {
+	if f.OnErrorFunc == nil {
+		return err
+	}
+	return f.OnErrorFunc(ctx, req, err)
+}
+
+func (r *Request) beforeSend(ctx context.Context, req *http.Request) error {
+	for _, i := range r.Interceptors {
+		if err := i.BeforeSend(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// afterReceive runs AfterReceive hooks in reverse order. If a hook fails, the error is passed to OnError hooks.
+func (r *Request) afterReceive(ctx context.Context, req *http.Request, resp *http.Response, result interface{}) error {
+	for i := len(r.Interceptors) - 1; i >= 0; i-- {
+		if err := r.Interceptors[i].AfterReceive(ctx, req, resp, result); err != nil {
+			return r.onError(ctx, req, err)
+		}
+	}
+	return nil
+}
+
+func (r *Request) onError(ctx context.Context, req *http.Request, err error) error {
+	for i := len(r.Interceptors) - 1; i >= 0; i-- {
+		err = r.Interceptors[i].OnError(ctx, req, err)
+	}
+	return err
+}
+
+// to enforce compile type check
+var _ Interceptor = InterceptorFuncs{}
@@ -0,0 +1,98 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/nirdosh17/go-sdk-template/apierror"
+	"github.com/nirdosh17/go-sdk-template/logger"
+	"github.com/nirdosh17/go-sdk-template/model"
+	"github.com/nirdosh17/go-sdk-template/test"
+)
+
+// recorder returns an interceptor which records the name of each hook called.
+func recorder(name string, calls *[]string) Interceptor {
+	return InterceptorFuncs{
+		BeforeSendFunc: func(ctx context.Context, req *http.Request) error {
+			*calls = append(*calls, name+".before")
+			req.Header.Add("X-Interceptors", name)
+			return nil
+		},
+		AfterReceiveFunc: func(ctx context.Context, req *http.Request, resp *http.Response, result interface{}) error {
+			*calls = append(*calls, name+".after")
+			return nil
+		},
+		OnErrorFunc: func(ctx context.Context, req *http.Request, err error) error {
+			*calls = append(*calls, name+".error")
+			return err
+		},
+	}
+}
+
+func TestRequest_Perform_interceptors(t *testing.T) {
+	json := `{"answer": "answer from AI", "confidenceScore": 73}`
+	mock := test.MockHTTPClient{StatusCode: 200, JSONBody: &json}
+
+	t.Run("success", func(t *testing.T) {
+		var calls []string
+		r := Request{Client: &mock, Logger: logger.NewDefaultLogger(), Interceptors: []Interceptor{recorder("first", &calls), recorder("second", &calls)}}
+
+		var a model.AIAnswer
+		err := r.Perform(context.Background(), "http://api.doesnotmatter.com", "POST", nil, &a)
+		test.ExpectNil(t, "Request.Perform", err)
+		test.ExpectEqual(t, "hooks", "[first.before second.before second.after first.after]", fmt.Sprint(calls))
+		test.ExpectEqual(t, "request headers", "[first second]", fmt.Sprint(mock.LastRequest.Header.Values("X-Interceptors")))
+	})
+
+	t.Run("decoded result", func(t *testing.T) {
+		var received *model.AIAnswer
+		r := Request{Client: &mock, Logger: logger.NewDefaultLogger(), Interceptors: []Interceptor{InterceptorFuncs{
+			AfterReceiveFunc: func(ctx context.Context, req *http.Request, resp *http.Response, result interface{}) error {
+				received = result.(*model.AIAnswer)
+				return nil
+			},
+		}}}
+
+		var a model.AIAnswer
+		r.Perform(context.Background(), "http://api.doesnotmatter.com", "POST", nil, &a)
+		test.ExpectEqual(t, "result.Answer", "answer from AI", received.Answer)
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		var calls []string
+		failing := test.MockHTTPClient{StatusCode: 500, JSONBody: &json}
+		r := Request{Client: &failing, Logger: logger.NewDefaultLogger(), Interceptors: []Interceptor{recorder("first", &calls), recorder("second", &calls)}}
+
+		err := r.Perform(context.Background(), "http://api.doesnotmatter.com", "POST", nil, nil)
+		test.ExpectEqual(t, "error", true, errors.Is(err, &apierror.ErrInternalServer))
+		test.ExpectEqual(t, "hooks", "[first.before second.before second.error first.error]", fmt.Sprint(calls))
+	})
+
+	t.Run("aborted by interceptor", func(t *testing.T) {
+		abort := errors.New("missing tenant")
+		replaced := errors.New("replaced error")
+		mock := test.MockHTTPClient{StatusCode: 200, JSONBody: &json}
+		r := Request{Client: &mock, Logger: logger.NewDefaultLogger(), Interceptors: []Interceptor{
+			InterceptorFuncs{
+				OnErrorFunc: func(ctx context.Context, req *http.Request, err error) error {
+					if errors.Is(err, abort) {
+						return replaced
+					}
+					return err
+				},
+			},
+			InterceptorFuncs{
+				BeforeSendFunc: func(ctx context.Context, req *http.Request) error {
+					return abort
+				},
+			},
+		}}
+
+		err := r.Perform(context.Background(), "http://api.doesnotmatter.com", "POST", nil, nil)
+		test.ExpectEqual(t, "error", replaced, err)
+		test.ExpectEqual(t, "request sent", false, mock.LastRequest != nil)
+	})
+}
@@ -0,0 +1,75 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/nirdosh17/go-sdk-template/apierror"
+	"github.com/nirdosh17/go-sdk-template/logger"
+)
+
+// debug writes a debug entry if debug mode is enabled.
+func (r *Request) debug(msg string, keyvals ...interface{}) {
+	if !r.Debug {
+		return
+	}
+	logger.Structured(logger.Verbose(r.Logger)).LogLevel(logger.SeverityDebug, msg, keyvals...)
+}
+
+// logOutcome writes a debug entry with the result of the request.
+func (r *Request) logOutcome(ctx context.Context, req *http.Request, resp *http.Response, err error, latency time.Duration) {
+	if !r.Debug {
+		return
+	}
+
+	var apiErr *apierror.APIError
+	isAPIErr := errors.As(err, &apiErr)
+
+	requestID := RequestID(req, resp)
+	if isAPIErr && apiErr.RequestID != "" {
+		requestID = apiErr.RequestID
+	}
+
+	keyvals := []interface{}{
+		"service", r.Service,
+		"method", req.Method,
+		"url", req.URL.String(),
+		"attempt", AttemptFromContext(ctx),
+		"request_id", requestID,
+		"latency", latency,
+	}
+	if resp != nil {
+		keyvals = append(keyvals, "status", resp.StatusCode)
+	}
+	if err == nil {
+		r.debug("request succeeded", keyvals...)
+		return
+	}
+
+	if isAPIErr {
+		keyvals = append(keyvals, "status", apiErr.StatusCode, "code", apiErr.ErrCode)
+	}
+	keyvals = append(keyvals, "error", err)
+	r.debug("request failed", keyvals...)
+}
+
+// logRetry writes a warning with the reason of the retry using the logger carried by ctx.
+func logRetry(ctx context.Context, attempt int, delay time.Duration, err error) {
+	keyvals := []interface{}{"attempt", attempt + 1, "delay", delay}
+
+	var apiErr *apierror.APIError
+	if errors.As(err, &apiErr) {
+		keyvals = append(keyvals, "code", apiErr.ErrCode)
+		if apiErr.StatusCode != 0 {
+			keyvals = append(keyvals, "status", apiErr.StatusCode)
+		}
+		if apiErr.RequestID != "" {
+			keyvals = append(keyvals, "request_id", apiErr.RequestID)
+		}
+	}
+	keyvals = append(keyvals, "error", err)
+
+	logger.FromContext(ctx).LogLevel(logger.SeverityWarn, "retrying request", keyvals...)
+}
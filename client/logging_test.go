@@ -0,0 +1,54 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nirdosh17/go-sdk-template/logger"
+	"github.com/nirdosh17/go-sdk-template/test"
+)
+
+func TestRetry_Run_logsRetries(t *testing.T) {
+	var entries []string
+	l := logger.LoggerFunc(func(args ...interface{}) {
+		entries = append(entries, fmt.Sprint(args...))
+	})
+	ctx := logger.NewContext(context.Background(), l)
+
+	json := `{"error": {"code": "OVERLOADED", "requestId": "req-1"}}`
+	mock := test.MockHTTPClient{StatusCode: 503, JSONBody: &json}
+	r := Request{Service: "chatai", Client: &mock, Logger: l}
+
+	retryer := &Retry{Delay: time.Millisecond, MaxRetries: 2}
+	retryer.Run(ctx, func(ctx context.Context) error {
+		return r.Perform(ctx, "http://api.doesnotmatter.com", "POST", nil, nil)
+	})
+
+	test.ExpectEqual(t, "number of entries", 1, len(entries))
+	test.ExpectEqual(t, "retry entry", true, strings.HasPrefix(entries[0], "WARN: retrying request attempt=2 delay=1ms code=INTERNAL_SERVER_ERROR status=503 request_id=req-1"))
+}
+
+func TestRequest_Perform_debugEntries(t *testing.T) {
+	var entries []string
+	l := logger.LoggerFunc(func(args ...interface{}) {
+		entries = append(entries, fmt.Sprint(args...))
+	})
+
+	json := `{"answer": "answer from AI", "confidenceScore": 73}`
+	mock := test.MockHTTPClient{StatusCode: 200, JSONBody: &json}
+	r := Request{Service: "chatai", Client: &mock, Logger: l, Debug: true}
+
+	ctx := ContextWithRequestID(context.Background(), "req-1")
+	err := r.Perform(ctx, "http://api.doesnotmatter.com", "POST", nil, nil)
+	test.ExpectNil(t, "Request.Perform", err)
+
+	test.ExpectEqual(t, "number of entries", 4, len(entries))
+	test.ExpectEqual(t, "sending entry", "DEBUG: sending request service=chatai method=POST url=http://api.doesnotmatter.com attempt=1 request_id=req-1", entries[0])
+	test.ExpectEqual(t, "request dump", true, strings.HasPrefix(entries[1], "DEBUG: HTTP request dump service=chatai"))
+	test.ExpectEqual(t, "response dump", true, strings.HasPrefix(entries[2], "DEBUG: HTTP response dump service=chatai"))
+	test.ExpectEqual(t, "outcome entry", true, strings.HasPrefix(entries[3], "DEBUG: request succeeded service=chatai method=POST url=http://api.doesnotmatter.com attempt=1 request_id=req-1"))
+	test.ExpectEqual(t, "outcome status", true, strings.HasSuffix(entries[3], "status=200"))
+}
@@ -0,0 +1,54 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/nirdosh17/go-sdk-template/apierror"
+	"github.com/nirdosh17/go-sdk-template/metrics"
+)
+
+// ObserveAttempt reports a retry to the metrics collector, if any, when ctx carries an attempt after the first one.
+// Services call it once per attempt of the retryer, so that regional failovers and credential renewals within
+// an attempt are not counted as retries.
+func (r *Request) ObserveAttempt(ctx context.Context) {
+	if r.Metrics == nil {
+		return
+	}
+	if attempt := AttemptFromContext(ctx); attempt > 1 {
+		r.Metrics.ObserveRetry(r.Service, attempt)
+	}
+}
+
+// observe reports the outcome of a request to the metrics collector, if any.
+func (r *Request) observe(ctx context.Context, req *http.Request, resp *http.Response, err error, latency time.Duration) {
+	if r.Metrics == nil {
+		return
+	}
+
+	m := metrics.Request{
+		Service: r.Service,
+		Method:  req.Method,
+		Latency: latency,
+		Attempt: AttemptFromContext(ctx),
+	}
+	if resp != nil {
+		m.StatusCode = resp.StatusCode
+	}
+
+	var apiErr *apierror.APIError
+	switch {
+	case errors.As(err, &apiErr):
+		m.ErrCode = apiErr.ErrCode
+		m.StatusCode = apiErr.StatusCode
+	case err != nil:
+		m.ErrCode = apierror.ErrUnhandled.ErrCode
+	}
+
+	r.Metrics.ObserveRequest(m)
+	if m.ErrCode == apierror.ErrRequestThrottled.ErrCode {
+		r.Metrics.ObserveThrottle(r.Service)
+	}
+}
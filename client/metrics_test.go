@@ -0,0 +1,76 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/nirdosh17/go-sdk-template/logger"
+	"github.com/nirdosh17/go-sdk-template/metrics"
+	"github.com/nirdosh17/go-sdk-template/test"
+)
+
+type recordingCollector struct {
+	requests  []metrics.Request
+	retries   []int
+	throttles int
+}
+
+func (c *recordingCollector) ObserveRequest(r metrics.Request) {
+	c.requests = append(c.requests, r)
+}
+
+func (c *recordingCollector) ObserveRetry(service string, attempt int) {
+	c.retries = append(c.retries, attempt)
+}
+
+func (c *recordingCollector) ObserveThrottle(service string) {
+	c.throttles++
+}
+
+func TestRequest_Perform_metrics(t *testing.T) {
+	json := `{"message": "slow down"}`
+	mock := test.MockHTTPClient{
+		StatusCode: 429,
+		JSONBody:   &json,
+		Header:     http.Header{"Retry-After": []string{"0"}},
+	}
+	collector := &recordingCollector{}
+	r := Request{Service: "chatai", Client: &mock, Logger: logger.NewDefaultLogger(), Metrics: collector}
+
+	retryer := &Retry{Delay: time.Millisecond, MaxRetries: 2}
+	retryer.Run(context.Background(), func(ctx context.Context) error {
+		r.ObserveAttempt(ctx)
+		return r.Perform(ctx, "http://api.doesnotmatter.com", "POST", nil, nil)
+	})
+
+	test.ExpectEqual(t, "number of requests", 2, len(collector.requests))
+	first := collector.requests[0]
+	test.ExpectEqual(t, "Request.Service", "chatai", first.Service)
+	test.ExpectEqual(t, "Request.Method", "POST", first.Method)
+	test.ExpectEqual(t, "Request.StatusCode", 429, first.StatusCode)
+	test.ExpectEqual(t, "Request.ErrCode", "TOO_MANY_REQUESTS", first.ErrCode)
+	test.ExpectEqual(t, "Request.Attempt", 1, first.Attempt)
+	test.ExpectEqual(t, "retries", "[2]", fmt.Sprint(collector.retries))
+	test.ExpectEqual(t, "throttles", 2, collector.throttles)
+}
+
+func TestRequest_Perform_metricsRetriesPerAttempt(t *testing.T) {
+	json := `{"message": "unavailable"}`
+	mock := test.MockHTTPClient{StatusCode: 503, JSONBody: &json}
+	collector := &recordingCollector{}
+	r := Request{Service: "chatai", Client: &mock, Logger: logger.NewDefaultLogger(), Metrics: collector}
+
+	// each attempt fails over to a second region
+	retryer := &Retry{Delay: time.Millisecond, MaxRetries: 2}
+	retryer.Run(context.Background(), func(ctx context.Context) error {
+		r.ObserveAttempt(ctx)
+		r.Perform(ctx, "http://eu.doesnotmatter.com", "POST", nil, nil)
+		return r.Perform(ctx, "http://us.doesnotmatter.com", "POST", nil, nil)
+	})
+
+	test.ExpectEqual(t, "number of requests", 4, len(collector.requests))
+	test.ExpectEqual(t, "retries", "[2]", fmt.Sprint(collector.retries))
+}
@@ -0,0 +1,231 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/nirdosh17/go-sdk-template/apierror"
+)
+
+const (
+	// DefaultMinRateFactor is the lowest fraction of the configured rate an adaptive limiter slows down to.
+	DefaultMinRateFactor = 0.1
+	// DefaultRateRecovery is the time an adaptive limiter takes to recover from the minimum to the configured rate.
+	DefaultRateRecovery = 30 * time.Second
+
+	// throttleFactor is the factor by which the rate is reduced after each throttled response.
+	throttleFactor = 0.5
+)
+
+// RateLimiter delays requests to keep the request rate under a limit.
+type RateLimiter interface {
+	// Wait blocks until the request of the service can be sent or the context is done.
+	Wait(ctx context.Context, service string) error
+}
+
+// AdaptiveRateLimiter is implemented by rate limiters which slow down when the server throttles requests.
+type AdaptiveRateLimiter interface {
+	// Throttled reports a throttled response to a request of the service.
+	Throttled(service string)
+}
+
+// Rate is the number of requests allowed per second with bursts of up to Burst requests.
+type Rate struct {
+	RPS float64
+	// Burst is the number of requests which can be sent at once. Defaults to 1.
+	Burst int
+}
+
+// TokenBucketLimiter is a token bucket rate limiter shared by all services. Services with their own rate have separate buckets.
+// It halves the rate of a bucket on every throttled response and recovers gradually to the configured rate.
+// It is safe for concurrent use.
+type TokenBucketLimiter struct {
+	// Rate is shared by services without their own rate. Requests are not limited if RPS is zero.
+	Rate Rate
+	// Services overrides the rate of the given services.
+	Services map[string]Rate
+	// MinRateFactor is the lowest fraction of the configured rate the limiter slows down to. Defaults to DefaultMinRateFactor.
+	MinRateFactor float64
+	// Recovery is the time taken to recover from the minimum to the configured rate. Defaults to DefaultRateRecovery.
+	Recovery time.Duration
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+// bucket holds the tokens of services sharing a rate.
+type bucket struct {
+	limit  Rate
+	rate   float64
+	tokens float64
+	last   time.Time
+}
+
+// NewTokenBucketLimiter returns a limiter allowing rps requests per second with bursts of up to burst requests.
+//
+// Example:
+//
+//	// 10 requests/sec in total, of which chatai can send up to 2
+//	l := client.NewTokenBucketLimiter(10, 5).WithService("chatai", 2, 1)
+func NewTokenBucketLimiter(rps float64, burst int) *TokenBucketLimiter {
+	return &TokenBucketLimiter{Rate: Rate{RPS: rps, Burst: burst}}
+}
+
+// WithService sets a separate rate for the service.
+func (l *TokenBucketLimiter) WithService(service string, rps float64, burst int) *TokenBucketLimiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.Services == nil {
+		l.Services = map[string]Rate{}
+	}
+	l.Services[service] = Rate{RPS: rps, Burst: burst}
+	delete(l.buckets, service)
+	return l
+}
+
+// Wait takes a token from the bucket of the service, waiting for it to be refilled if empty.
+// The token is returned if the context is done before the wait is over.
+func (l *TokenBucketLimiter) Wait(ctx context.Context, service string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	b := l.bucket(service)
+	if b == nil {
+		l.mu.Unlock()
+		return nil
+	}
+	wait := b.take(time.Now(), l.recoveryRate(b))
+	l.mu.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+	if err := sleep(ctx, wait); err != nil {
+		l.mu.Lock()
+		b.tokens++
+		l.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// Throttled halves the current rate of the service bucket, down to MinRateFactor of the configured rate.
+func (l *TokenBucketLimiter) Throttled(service string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b := l.bucket(service)
+	if b == nil {
+		return
+	}
+	b.refill(time.Now(), l.recoveryRate(b))
+	b.rate = math.Max(b.rate*throttleFactor, b.limit.RPS*l.minRateFactor())
+	// drop the burst so that the slower rate takes effect at once
+	b.tokens = math.Min(b.tokens, 0)
+}
+
+// CurrentRate returns the current requests per second allowed for the service. Zero if it is not limited.
+func (l *TokenBucketLimiter) CurrentRate(service string) float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b := l.bucket(service)
+	if b == nil {
+		return 0
+	}
+	b.refill(time.Now(), l.recoveryRate(b))
+	return b.rate
+}
+
+// bucket returns the bucket of the service, nil if requests of the service are not limited. Caller must hold the lock.
+func (l *TokenBucketLimiter) bucket(service string) *bucket {
+	key, limit := "", l.Rate
+	if r, ok := l.Services[service]; ok {
+		key, limit = service, r
+	}
+	if limit.RPS <= 0 {
+		return nil
+	}
+
+	if l.buckets == nil {
+		l.buckets = map[string]*bucket{}
+	}
+	b, ok := l.buckets[key]
+	if !ok {
+		if limit.Burst < 1 {
+			limit.Burst = 1
+		}
+		b = &bucket{limit: limit, rate: limit.RPS, tokens: float64(limit.Burst), last: time.Now()}
+		l.buckets[key] = b
+	}
+	return b
+}
+
+func (l *TokenBucketLimiter) minRateFactor() float64 {
+	if l.MinRateFactor > 0 {
+		return l.MinRateFactor
+	}
+	return DefaultMinRateFactor
+}
+
+// recoveryRate returns the increase of rate per second while recovering from throttling.
+func (l *TokenBucketLimiter) recoveryRate(b *bucket) float64 {
+	recovery := l.Recovery
+	if recovery <= 0 {
+		recovery = DefaultRateRecovery
+	}
+	return b.limit.RPS * (1 - l.minRateFactor()) / recovery.Seconds()
+}
+
+// refill adds tokens accumulated since the last refill and recovers the rate towards the limit.
+func (b *bucket) refill(now time.Time, recoveryRate float64) {
+	elapsed := now.Sub(b.last).Seconds()
+	if elapsed <= 0 {
+		return
+	}
+	b.last = now
+	b.tokens = math.Min(b.tokens+elapsed*b.rate, float64(b.limit.Burst))
+	b.rate = math.Min(b.rate+elapsed*recoveryRate, b.limit.RPS)
+}
+
+// take reserves a token and returns the time to wait until it is available.
+func (b *bucket) take(now time.Time, recoveryRate float64) time.Duration {
+	b.refill(now, recoveryRate)
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// waitRateLimit waits for the rate limiter, if any.
+func (r *Request) waitRateLimit(ctx context.Context) error {
+	if r.RateLimiter == nil {
+		return nil
+	}
+	if err := r.RateLimiter.Wait(ctx, r.Service); err != nil {
+		return apierror.ErrSDK.Record(fmt.Errorf("rate limiter: %w", err))
+	}
+	return nil
+}
+
+// adaptRateLimit slows down the rate limiter if the server has throttled the request.
+func (r *Request) adaptRateLimit(err error) {
+	l, ok := r.RateLimiter.(AdaptiveRateLimiter)
+	if ok && errors.Is(err, &apierror.ErrRequestThrottled) {
+		l.Throttled(r.Service)
+	}
+}
+
+// to enforce compile type check
+var (
+	_ RateLimiter         = (*TokenBucketLimiter)(nil)
+	_ AdaptiveRateLimiter = (*TokenBucketLimiter)(nil)
+)
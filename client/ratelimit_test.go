@@ -0,0 +1,102 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nirdosh17/go-sdk-template/apierror"
+	"github.com/nirdosh17/go-sdk-template/test"
+)
+
+func TestTokenBucketLimiter_Wait(t *testing.T) {
+	l := NewTokenBucketLimiter(50, 2)
+
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		test.ExpectNil(t, "Wait", l.Wait(context.Background(), "chatai"))
+	}
+	elapsed := time.Since(start)
+
+	// burst of 2 is sent at once, the other 2 requests wait 20ms each
+	test.ExpectEqual(t, "waited", true, elapsed >= 35*time.Millisecond)
+	test.ExpectEqual(t, "not waited too long", true, elapsed < 200*time.Millisecond)
+}
+
+func TestTokenBucketLimiter_Wait_unlimited(t *testing.T) {
+	l := &TokenBucketLimiter{}
+	for i := 0; i < 100; i++ {
+		test.ExpectNil(t, "Wait", l.Wait(context.Background(), "chatai"))
+	}
+	test.ExpectEqual(t, "CurrentRate", 0.0, l.CurrentRate("chatai"))
+}
+
+func TestTokenBucketLimiter_Wait_contextDone(t *testing.T) {
+	l := NewTokenBucketLimiter(1, 1)
+	l.Wait(context.Background(), "chatai")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := l.Wait(ctx, "chatai")
+	test.ExpectEqual(t, "deadline exceeded", context.DeadlineExceeded, err)
+
+	// token of the cancelled wait is returned
+	test.ExpectEqual(t, "tokens", true, l.buckets[""].tokens > -1)
+}
+
+func TestTokenBucketLimiter_WithService(t *testing.T) {
+	l := NewTokenBucketLimiter(1, 1).WithService("chatai", 100, 10)
+
+	// chatai has its own bucket, other services share the default one
+	for i := 0; i < 10; i++ {
+		test.ExpectNil(t, "Wait chatai", l.Wait(context.Background(), "chatai"))
+	}
+	test.ExpectNil(t, "Wait search", l.Wait(context.Background(), "search"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	test.ExpectNotNil(t, "Wait translate", l.Wait(ctx, "translate"))
+	test.ExpectEqual(t, "chatai rate", 100.0, l.CurrentRate("chatai"))
+}
+
+func TestTokenBucketLimiter_Throttled(t *testing.T) {
+	l := NewTokenBucketLimiter(100, 10)
+	l.Recovery = time.Hour
+
+	l.Throttled("chatai")
+	test.ExpectEqual(t, "halved rate", true, l.CurrentRate("chatai") < 51)
+	l.Throttled("chatai")
+	l.Throttled("chatai")
+	l.Throttled("chatai")
+	l.Throttled("chatai")
+	test.ExpectEqual(t, "min rate", true, l.CurrentRate("chatai") < 11)
+	test.ExpectEqual(t, "min rate floor", true, l.CurrentRate("chatai") >= 10)
+}
+
+func TestTokenBucketLimiter_Throttled_recovers(t *testing.T) {
+	l := NewTokenBucketLimiter(100, 10)
+	l.Recovery = 50 * time.Millisecond
+
+	l.Throttled("chatai")
+	time.Sleep(60 * time.Millisecond)
+	test.ExpectEqual(t, "recovered rate", 100.0, l.CurrentRate("chatai"))
+}
+
+func TestRequest_Perform_rateLimiter(t *testing.T) {
+	json := `{"error": {"code": "SLOW_DOWN"}}`
+	mock := test.MockHTTPClient{StatusCode: 429, JSONBody: &json}
+	l := NewTokenBucketLimiter(100, 1)
+	l.Recovery = time.Hour
+	r := Request{Service: "chatai", Client: &mock, RateLimiter: l}
+
+	err := r.Perform(context.Background(), "http://api.doesnotmatter.com", "POST", nil, nil)
+	test.ExpectEqual(t, "throttled", true, errors.Is(err, &apierror.ErrRequestThrottled))
+	test.ExpectEqual(t, "adapted rate", true, l.CurrentRate("chatai") < 51)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = r.Perform(ctx, "http://api.doesnotmatter.com", "POST", nil, nil)
+	test.ExpectEqual(t, "rate limiter error", true, errors.Is(err, &apierror.ErrSDK))
+	test.ExpectEqual(t, "context error", true, errors.Is(err, context.Canceled))
+}
@@ -0,0 +1,154 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"strings"
+)
+
+const (
+	// DefaultMaxDumpBodyBytes is the number of body bytes kept in debug dumps.
+	DefaultMaxDumpBodyBytes = 4096
+
+	// redacted replaces values of sensitive headers and JSON fields in debug dumps.
+	redacted = "[REDACTED]"
+)
+
+// Redaction controls what is written by the http request and response dumps in debug mode.
+type Redaction struct {
+	// Headers are replaced with "[REDACTED]" in dumps. Matched case-insensitively.
+	Headers []string
+	// JSONFields are object keys whose values are replaced with "[REDACTED]" at any depth of a JSON body.
+	// Matched case-insensitively. Bodies which are not valid JSON are only truncated.
+	JSONFields []string
+	// MaxBodyBytes truncates dumped bodies to the given size. Defaults to DefaultMaxDumpBodyBytes if zero, no limit if negative.
+	MaxBodyBytes int
+	// MetadataOnly omits bodies from dumps, leaving the request line, status line and headers.
+	MetadataOnly bool
+}
+
+// DefaultRedaction hides credentials, cookies and the questions sent by the user.
+func DefaultRedaction() Redaction {
+	return Redaction{
+		Headers:      []string{"x-api-key", "Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"},
+		JSONFields:   []string{"query", "history"},
+		MaxBodyBytes: DefaultMaxDumpBodyBytes,
+	}
+}
+
+// redaction returns redaction rules of the request, falling back to DefaultRedaction.
+func (r *Request) redaction() Redaction {
+	if r.Redaction == nil {
+		return DefaultRedaction()
+	}
+	return *r.Redaction
+}
+
+// DumpRequest returns the redacted wire representation of the request. Body of the request is left intact.
+func (rd Redaction) DumpRequest(req *http.Request) ([]byte, error) {
+	out := req.Clone(req.Context())
+	out.Header = rd.header(req.Header)
+	out.Body = http.NoBody
+	out.ContentLength = 0
+
+	if !rd.MetadataOnly && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		b, err := io.ReadAll(body)
+		body.Close()
+		if err != nil {
+			return nil, err
+		}
+		b = rd.body(b)
+		out.Body = io.NopCloser(bytes.NewReader(b))
+		out.ContentLength = int64(len(b))
+	}
+	return httputil.DumpRequestOut(out, !rd.MetadataOnly)
+}
+
+// DumpResponse returns the redacted wire representation of the response with the given body.
+// Only headers are dumped if body is nil, e.g. for streamed responses.
+func (rd Redaction) DumpResponse(resp *http.Response, body []byte) ([]byte, error) {
+	out := *resp
+	out.Header = rd.header(resp.Header)
+	out.Body = http.NoBody
+
+	includeBody := body != nil && !rd.MetadataOnly
+	if includeBody {
+		b := rd.body(body)
+		out.Body = io.NopCloser(bytes.NewReader(b))
+		out.ContentLength = int64(len(b))
+	}
+	return httputil.DumpResponse(&out, includeBody)
+}
+
+// header returns a copy of h with sensitive values redacted.
+func (rd Redaction) header(h http.Header) http.Header {
+	out := h.Clone()
+	if out == nil {
+		return http.Header{}
+	}
+	for _, name := range rd.Headers {
+		for key := range out {
+			if strings.EqualFold(key, name) {
+				out[key] = []string{redacted}
+			}
+		}
+	}
+	return out
+}
+
+// body redacts JSON fields of b and truncates it to MaxBodyBytes.
+func (rd Redaction) body(b []byte) []byte {
+	if len(rd.JSONFields) > 0 {
+		var v interface{}
+		if err := json.Unmarshal(b, &v); err == nil {
+			if rb, err := json.Marshal(rd.redactJSON(v)); err == nil {
+				b = rb
+			}
+		}
+	}
+
+	limit := rd.MaxBodyBytes
+	if limit == 0 {
+		limit = DefaultMaxDumpBodyBytes
+	}
+	if limit > 0 && len(b) > limit {
+		b = append(b[:limit:limit], fmt.Sprintf("... (%d bytes truncated)", len(b)-limit)...)
+	}
+	return b
+}
+
+// redactJSON replaces values of matching object keys in decoded JSON value v.
+func (rd Redaction) redactJSON(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for key, value := range t {
+			if rd.isRedactedField(key) {
+				t[key] = redacted
+				continue
+			}
+			t[key] = rd.redactJSON(value)
+		}
+	case []interface{}:
+		for i, value := range t {
+			t[i] = rd.redactJSON(value)
+		}
+	}
+	return v
+}
+
+func (rd Redaction) isRedactedField(key string) bool {
+	for _, f := range rd.JSONFields {
+		if strings.EqualFold(f, key) {
+			return true
+		}
+	}
+	return false
+}
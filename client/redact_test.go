@@ -0,0 +1,90 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/nirdosh17/go-sdk-template/logger"
+	"github.com/nirdosh17/go-sdk-template/test"
+)
+
+func TestRedaction_DumpRequest(t *testing.T) {
+	body := `{"query":"my secret question","meta":{"History":["earlier question"]},"model":"small"}`
+	req, _ := http.NewRequest("POST", "http://api.doesnotmatter.com/chatai", bytes.NewBufferString(body))
+	req.Header.Set("x-api-key", "secret-key")
+	req.Header.Set("Content-Type", "application/json")
+
+	dump, err := DefaultRedaction().DumpRequest(req)
+	test.ExpectNil(t, "DumpRequest", err)
+
+	s := string(dump)
+	test.ExpectEqual(t, "api key hidden", false, strings.Contains(s, "secret-key"))
+	test.ExpectEqual(t, "api key header redacted", true, strings.Contains(s, "X-Api-Key: [REDACTED]"))
+	test.ExpectEqual(t, "other headers kept", true, strings.Contains(s, "Content-Type: application/json"))
+	test.ExpectEqual(t, "query hidden", false, strings.Contains(s, "secret question"))
+	test.ExpectEqual(t, "nested field hidden", false, strings.Contains(s, "earlier question"))
+	test.ExpectEqual(t, "other fields kept", true, strings.Contains(s, `"model":"small"`))
+
+	sent, _ := io.ReadAll(req.Body)
+	test.ExpectEqual(t, "request body", body, string(sent))
+	test.ExpectEqual(t, "request header", "secret-key", req.Header.Get("x-api-key"))
+}
+
+func TestRedaction_DumpRequest_metadataOnly(t *testing.T) {
+	req, _ := http.NewRequest("POST", "http://api.doesnotmatter.com/chatai", bytes.NewBufferString(`{"model":"small"}`))
+
+	dump, err := Redaction{MetadataOnly: true}.DumpRequest(req)
+	test.ExpectNil(t, "DumpRequest", err)
+	test.ExpectEqual(t, "request line", true, strings.HasPrefix(string(dump), "POST /chatai HTTP/1.1"))
+	test.ExpectEqual(t, "body omitted", false, strings.Contains(string(dump), "small"))
+}
+
+func TestRedaction_DumpResponse(t *testing.T) {
+	resp := &http.Response{
+		StatusCode: 200,
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     http.Header{"Set-Cookie": {"session=abc"}},
+	}
+
+	dump, err := Redaction{Headers: []string{"set-cookie"}, MaxBodyBytes: 5}.DumpResponse(resp, []byte("0123456789"))
+	test.ExpectNil(t, "DumpResponse", err)
+
+	s := string(dump)
+	test.ExpectEqual(t, "cookie redacted", true, strings.Contains(s, "Set-Cookie: [REDACTED]"))
+	test.ExpectEqual(t, "body truncated", true, strings.HasSuffix(s, "01234... (5 bytes truncated)"))
+	test.ExpectEqual(t, "response header", "session=abc", resp.Header.Get("Set-Cookie"))
+}
+
+func TestRedaction_DumpResponse_headersOnly(t *testing.T) {
+	resp := &http.Response{StatusCode: 200, ProtoMajor: 1, ProtoMinor: 1, Header: http.Header{}, ContentLength: -1}
+
+	dump, err := DefaultRedaction().DumpResponse(resp, nil)
+	test.ExpectNil(t, "DumpResponse", err)
+	test.ExpectEqual(t, "status line", true, strings.HasPrefix(string(dump), "HTTP/1.1 200 OK\r\n"))
+}
+
+func TestRequest_Perform_redactsDumps(t *testing.T) {
+	var entries []string
+	l := logger.LoggerFunc(func(args ...interface{}) {
+		entries = append(entries, fmt.Sprint(args...))
+	})
+
+	json := `{"answer": "answer from AI", "confidenceScore": 73}`
+	mock := test.MockHTTPClient{StatusCode: 200, JSONBody: &json}
+	r := Request{Service: "chatai", Client: &mock, Logger: l, Debug: true, APIKey: "secret-key"}
+
+	err := r.Perform(context.Background(), "http://api.doesnotmatter.com", "POST", map[string]string{"query": "my secret question"}, nil)
+	test.ExpectNil(t, "Request.Perform", err)
+
+	test.ExpectEqual(t, "number of entries", 4, len(entries))
+	test.ExpectEqual(t, "api key hidden", false, strings.Contains(entries[1], "secret-key"))
+	test.ExpectEqual(t, "query hidden", false, strings.Contains(entries[1], "secret question"))
+	test.ExpectEqual(t, "response dumped", true, strings.Contains(entries[2], "answer from AI"))
+	test.ExpectEqual(t, "api key sent", "secret-key", mock.LastRequest.Header.Get("x-api-key"))
+}
@@ -10,11 +10,13 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"net/http/httputil"
 	"time"
 
 	"github.com/nirdosh17/go-sdk-template/apierror"
+	"github.com/nirdosh17/go-sdk-template/credentials"
 	"github.com/nirdosh17/go-sdk-template/logger"
+	"github.com/nirdosh17/go-sdk-template/metrics"
+	"go.opentelemetry.io/otel/propagation"
 )
 
 const (
@@ -27,11 +29,29 @@ type Requester interface {
 }
 
 type Request struct {
-	Client Client
+	// Service is the name of the service sending the request. It is used to label metrics.
+	Service string
+	Client  Client
+	// APIKey is sent in the x-api-key header unless Credentials is set.
 	APIKey string
-	Logger logger.Logger
-	// Debug flag activates verbose mode. It prints out http request and response objects if set to true.
+	// Credentials provides the API key for each request. Cached credentials are discarded and the request
+	// is sent once more if the server rejects them with 401 Unauthorized. Takes precedence over APIKey.
+	Credentials credentials.Provider
+	Logger      logger.Logger
+	// Debug flag activates verbose mode. It logs each step of the request along with http request and response dumps if set to true.
 	Debug bool
+	// Headers are added to every request. They cannot override the x-api-key header.
+	Headers http.Header
+	// Interceptors are called around every request in the given order. See Interceptor.
+	Interceptors []Interceptor
+	// Propagator injects trace context of the request context into headers, e.g. W3C traceparent. Skipped if nil.
+	Propagator propagation.TextMapPropagator
+	// Metrics receives request, retry and throttling measurements. Skipped if nil.
+	Metrics metrics.Collector
+	// RateLimiter delays requests to keep the rate under a limit. Throttled responses are reported to adaptive limiters. Skipped if nil.
+	RateLimiter RateLimiter
+	// Redaction rules applied to http dumps in debug mode. Defaults to DefaultRedaction if nil.
+	Redaction *Redaction
 }
 
 // DefaultClient returns a HTTP client with default timeout.
@@ -43,68 +63,194 @@ func DefaultClient() Client {
 // It will include "requestBody" in the request if it is non-nil.
 // Response from server will be deserialized to "target" interface.
 func (r *Request) Perform(ctx context.Context, url string, method string, requestBody interface{}, target interface{}) error {
-	var (
-		toSend    *bytes.Buffer = &bytes.Buffer{}
-		respBytes []byte
-	)
+	err := r.perform(ctx, url, method, requestBody, target)
+	if r.renewCredentials(ctx, err) {
+		err = r.perform(ctx, url, method, requestBody, target)
+	}
+	return err
+}
 
-	if requestBody != nil {
-		b, err := json.Marshal(requestBody)
-		if err != nil {
-			return apierror.ErrInvalidRequestBody.Record(fmt.Errorf("serialization failure: %v", err))
-		}
-		toSend = bytes.NewBuffer(b)
+// perform sends a single request and runs interceptors and instrumentation around it.
+func (r *Request) perform(ctx context.Context, url string, method string, requestBody interface{}, target interface{}) error {
+	request, err := r.newRequest(ctx, url, method, requestBody)
+	if err != nil {
+		return err
 	}
 
-	request, err := http.NewRequestWithContext(ctx, method, url, toSend)
+	if err := r.waitRateLimit(ctx); err != nil {
+		return err
+	}
+
+	start := time.Now()
+	resp, err := r.do(request, target)
+	r.adaptRateLimit(err)
+	r.observe(ctx, request, resp, err, time.Since(start))
+	r.logOutcome(ctx, request, resp, err, time.Since(start))
+	if err != nil {
+		return r.onError(ctx, request, err)
+	}
+	return r.afterReceive(ctx, request, resp, target)
+}
+
+// do sends the request and deserializes the response to "target". Response body is closed before returning.
+func (r *Request) do(request *http.Request, target interface{}) (*http.Response, error) {
+	resp, err := r.send(request)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBytes, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return apierror.ErrInvalidRequestBody.Record(err)
+		return nil, apierror.ErrSDK.Record(fmt.Errorf("failed reading response body: %w", err))
 	}
-	request.Header.Add("x-api-key", r.APIKey)
+
 	if r.Debug {
-		dump, dErr := httputil.DumpRequestOut(request, true)
+		dump, dErr := r.redaction().DumpResponse(resp, respBytes)
 		if dErr == nil {
-			r.Logger.Log(fmt.Sprintf("HTTP request dump:\n%s\n", string(dump)))
+			r.debug("HTTP response dump", "service", r.Service, "dump", "\n"+string(dump))
 		}
 	}
 
-	resp, err := r.Client.Do(request)
+	status := resp.StatusCode
+	if status >= 400 {
+		return nil, withRequestID(serverError(status, resp.Header, respBytes), request, resp)
+	}
+
+	if target != nil {
+		err = json.Unmarshal(respBytes, target)
+		if err != nil {
+			return nil, apierror.ErrResponseDeserialization.Record(err)
+		}
+	}
+
+	if status >= 200 && status < 300 {
+		return resp, nil
+	}
+	// 3XX not handled
+	return nil, apierror.ErrUnhandled.Record(fmt.Errorf("server error %d", status))
+}
+
+// PerformStream sends the request and returns the response body as a stream of Server-Sent Events.
+// Only the connection phase is covered by the returned error, so it can safely be wrapped by a Retryer.
+// Errors occurring while reading the events are reported by EventStream.Err.
+// Caller must close the returned stream.
+func (r *Request) PerformStream(ctx context.Context, url string, method string, requestBody interface{}) (*EventStream, error) {
+	stream, err := r.performStream(ctx, url, method, requestBody)
+	if r.renewCredentials(ctx, err) {
+		stream, err = r.performStream(ctx, url, method, requestBody)
+	}
+	return stream, err
+}
+
+// performStream opens a single stream and runs interceptors and instrumentation around it.
+func (r *Request) performStream(ctx context.Context, url string, method string, requestBody interface{}) (*EventStream, error) {
+	request, err := r.newRequest(ctx, url, method, requestBody)
 	if err != nil {
-		return apierror.ErrSDK.Record(fmt.Errorf("api request failure: %w", err))
+		return nil, err
+	}
+	request.Header.Set("Accept", "text/event-stream")
+
+	if err := r.waitRateLimit(ctx); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	stream, resp, err := r.doStream(request)
+	r.adaptRateLimit(err)
+	r.observe(ctx, request, resp, err, time.Since(start))
+	r.logOutcome(ctx, request, resp, err, time.Since(start))
+	if err != nil {
+		return nil, r.onError(ctx, request, err)
+	}
+	if err := r.afterReceive(ctx, request, resp, stream); err != nil {
+		stream.Close()
+		return nil, err
+	}
+	return stream, nil
+}
+
+// doStream sends the request and wraps successful response body in an EventStream.
+func (r *Request) doStream(request *http.Request) (*EventStream, *http.Response, error) {
+	resp, err := r.send(request)
+	if err != nil {
+		return nil, nil, err
 	}
-	defer resp.Body.Close()
 
 	if r.Debug {
-		dump, dErr := httputil.DumpResponse(resp, true)
+		// body is not dumped as it would consume the stream
+		dump, dErr := r.redaction().DumpResponse(resp, nil)
 		if dErr == nil {
-			r.Logger.Log(fmt.Sprintf("HTTP response dump:\n%s\n", string(dump)))
+			r.debug("HTTP response dump", "service", r.Service, "dump", "\n"+string(dump))
 		}
 	}
 
 	status := resp.StatusCode
-	if status >= 500 {
-		return &apierror.ErrInternalServer
+	if status >= 200 && status < 300 {
+		return NewEventStream(resp.Body), resp, nil
+	}
+	defer resp.Body.Close()
+
+	if status >= 400 {
+		respBytes, _ := io.ReadAll(resp.Body)
+		return nil, nil, withRequestID(serverError(status, resp.Header, respBytes), request, resp)
+	}
+	return nil, nil, apierror.ErrUnhandled.Record(fmt.Errorf("server error %d", status))
+}
+
+// send runs BeforeSend interceptors and sends the request to the server.
+func (r *Request) send(request *http.Request) (*http.Response, error) {
+	if err := r.beforeSend(request.Context(), request); err != nil {
+		return nil, err
+	}
+
+	r.debug("sending request", "service", r.Service, "method", request.Method, "url", request.URL.String(), "attempt", AttemptFromContext(request.Context()),
+		"request_id", request.Header.Get(RequestIDHeader))
+	if r.Debug {
+		dump, dErr := r.redaction().DumpRequest(request)
+		if dErr == nil {
+			r.debug("HTTP request dump", "service", r.Service, "dump", "\n"+string(dump))
+		}
 	}
 
-	respBytes, err = io.ReadAll(resp.Body)
+	start := time.Now()
+	resp, err := r.Client.Do(request)
+	recordRoundTrip(request.Context(), time.Since(start))
 	if err != nil {
-		return apierror.ErrSDK.Record(fmt.Errorf("failed reading response body: %w", err))
+		return nil, withRequestID(apierror.ErrSDK.Record(fmt.Errorf("api request failure: %w", err)), request, nil)
 	}
+	return resp, nil
+}
 
-	if target != nil {
-		err = json.Unmarshal(respBytes, target)
+// newRequest builds authenticated http request with JSON encoded "requestBody".
+func (r *Request) newRequest(ctx context.Context, url string, method string, requestBody interface{}) (*http.Request, error) {
+	toSend := &bytes.Buffer{}
+
+	if requestBody != nil {
+		b, err := json.Marshal(requestBody)
 		if err != nil {
-			return apierror.ErrResponseDeserialization.Record(err)
+			return nil, apierror.ErrInvalidRequestBody.Record(fmt.Errorf("serialization failure: %v", err))
 		}
+		toSend = bytes.NewBuffer(b)
+	}
+
+	key, err := r.apiKey(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	switch {
-	case status >= 200 && status < 300:
-		return nil
-	case status >= 400 && status < 500:
-		return apierror.ErrInvalidRequestBody.Record(fmt.Errorf("server response: %v", string(respBytes)))
-	default:
-		// 3XX not handled
-		return apierror.ErrUnhandled.Record(fmt.Errorf("server error %d", status))
+	request, err := http.NewRequestWithContext(ctx, method, url, toSend)
+	if err != nil {
+		return nil, apierror.ErrInvalidRequestBody.Record(err)
+	}
+	for k, v := range r.Headers {
+		request.Header[k] = append([]string(nil), v...)
+	}
+	request.Header.Set("x-api-key", key)
+	setIdempotencyKey(ctx, request)
+	setRequestID(ctx, request)
+	if r.Propagator != nil {
+		r.Propagator.Inject(ctx, propagation.HeaderCarrier(request.Header))
 	}
+	return request, nil
 }
@@ -74,3 +74,15 @@ func TestRequest_Perform(t *testing.T) {
 		test.ExpectEqual(t, "Request.Perform", "RESPONSE_DESERIALIZATION_ERROR", apiErr.ErrCode)
 	})
 }
+
+func TestRequest_Perform_headers(t *testing.T) {
+	mock := test.MockHTTPClient{StatusCode: 200}
+	headers := http.Header{"X-Team": {"search"}, "X-Api-Key": {"spoofed"}}
+	r := Request{Client: &mock, APIKey: "apiKey", Headers: headers}
+
+	err := r.Perform(context.Background(), "http://api.doesnotmatter.com", "POST", nil, nil)
+	test.ExpectNil(t, "Request.Perform", err)
+	test.ExpectEqual(t, "X-Team", "search", mock.LastRequest.Header.Get("X-Team"))
+	test.ExpectEqual(t, "x-api-key", "apiKey", mock.LastRequest.Header.Get("x-api-key"))
+	test.ExpectEqual(t, "x-api-key values", 1, len(mock.LastRequest.Header.Values("x-api-key")))
+}
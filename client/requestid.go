@@ -0,0 +1,74 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/nirdosh17/go-sdk-template/apierror"
+)
+
+// RequestIDHeader carries the ID which correlates a request with the server logs. The server sends back the ID it
+// has logged the request with in the same header.
+const RequestIDHeader = "X-Request-ID"
+
+type requestIDKey struct{}
+
+// ContextWithRequestID returns a copy of ctx carrying the request ID sent in the X-Request-ID header, e.g. the ID of
+// the incoming request being served, to correlate logs across services. All attempts of a call are sent with the same ID.
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the request ID attached to ctx, or an empty string if there is none.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// EnsureRequestID returns ctx as is if it carries a request ID, otherwise a copy of ctx carrying a new ID.
+// Services call it once per call before running the retryer, so that all attempts are sent with the same ID.
+func EnsureRequestID(ctx context.Context) context.Context {
+	if RequestIDFromContext(ctx) != "" {
+		return ctx
+	}
+	return ContextWithRequestID(ctx, NewRequestID())
+}
+
+// NewRequestID returns a random version 4 UUID.
+func NewRequestID() string {
+	return newUUID()
+}
+
+// RequestID returns the ID of the request reported by the server in the X-Request-ID response header.
+// It falls back to the ID sent with the request if the server has not reported one.
+func RequestID(req *http.Request, resp *http.Response) string {
+	if resp != nil {
+		if id := resp.Header.Get(RequestIDHeader); id != "" {
+			return id
+		}
+	}
+	if req != nil {
+		return req.Header.Get(RequestIDHeader)
+	}
+	return ""
+}
+
+// setRequestID adds the request ID of ctx, or a new one, to the request unless the header is already set.
+func setRequestID(ctx context.Context, req *http.Request) {
+	if req.Header.Get(RequestIDHeader) != "" {
+		return
+	}
+	id := RequestIDFromContext(ctx)
+	if id == "" {
+		id = NewRequestID()
+	}
+	req.Header.Set(RequestIDHeader, id)
+}
+
+// withRequestID sets the request ID of the error unless the server has reported one in the error body.
+func withRequestID(err *apierror.APIError, req *http.Request, resp *http.Response) *apierror.APIError {
+	if err.RequestID == "" {
+		err.RequestID = RequestID(req, resp)
+	}
+	return err
+}
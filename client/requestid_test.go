@@ -0,0 +1,63 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/nirdosh17/go-sdk-template/apierror"
+	"github.com/nirdosh17/go-sdk-template/test"
+)
+
+func TestRequest_Perform_requestID(t *testing.T) {
+	json := `{"answer": "yes"}`
+	mock := test.MockHTTPClient{StatusCode: 200, JSONBody: &json}
+	r := Request{Client: &mock}
+
+	ctx := ContextWithRequestID(context.Background(), "req-1")
+	r.Perform(ctx, "http://api.doesnotmatter.com", "GET", nil, nil)
+	test.ExpectEqual(t, "from context", "req-1", mock.LastRequest.Header.Get(RequestIDHeader))
+
+	r.Perform(context.Background(), "http://api.doesnotmatter.com", "GET", nil, nil)
+	first := mock.LastRequest.Header.Get(RequestIDHeader)
+	r.Perform(context.Background(), "http://api.doesnotmatter.com", "GET", nil, nil)
+	test.ExpectEqual(t, "generated", true, first != "")
+	test.ExpectEqual(t, "generated per request", true, first != mock.LastRequest.Header.Get(RequestIDHeader))
+
+	r.Headers = http.Header{}
+	r.Headers.Set(RequestIDHeader, "explicit")
+	r.Perform(ctx, "http://api.doesnotmatter.com", "GET", nil, nil)
+	test.ExpectEqual(t, "explicit header", "explicit", mock.LastRequest.Header.Get(RequestIDHeader))
+}
+
+func TestRequest_Perform_errorRequestID(t *testing.T) {
+	ctx := ContextWithRequestID(context.Background(), "req-1")
+	requestID := func(mock *test.MockHTTPClient) string {
+		err := (&Request{Client: mock}).Perform(ctx, "http://api.doesnotmatter.com", "POST", nil, nil)
+		var apiErr *apierror.APIError
+		errors.As(err, &apiErr)
+		return apiErr.RequestID
+	}
+
+	body := `{"error": {"message": "unavailable", "requestId": "srv-body"}}`
+	test.ExpectEqual(t, "from body", "srv-body", requestID(&test.MockHTTPClient{StatusCode: 503, JSONBody: &body}))
+
+	header := http.Header{}
+	header.Set(RequestIDHeader, "srv-header")
+	test.ExpectEqual(t, "from header", "srv-header", requestID(&test.MockHTTPClient{StatusCode: 503, Header: header}))
+	test.ExpectEqual(t, "sent", "req-1", requestID(&test.MockHTTPClient{StatusCode: 503}))
+	test.ExpectEqual(t, "transport error", "req-1", requestID(&test.MockHTTPClient{Err: errors.New("connection refused")}))
+}
+
+func TestRequestID(t *testing.T) {
+	req, _ := http.NewRequest("GET", "http://api.doesnotmatter.com", nil)
+	req.Header.Set(RequestIDHeader, "req-1")
+	resp := &http.Response{Header: http.Header{}}
+	resp.Header.Set(RequestIDHeader, "srv-1")
+
+	test.ExpectEqual(t, "server", "srv-1", RequestID(req, resp))
+	test.ExpectEqual(t, "sent", "req-1", RequestID(req, &http.Response{Header: http.Header{}}))
+	test.ExpectEqual(t, "no response", "req-1", RequestID(req, nil))
+	test.ExpectEqual(t, "none", "", RequestID(nil, nil))
+}
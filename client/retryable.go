@@ -0,0 +1,93 @@
+package client
+
+import (
+	"context"
+	"errors"
+
+	"github.com/nirdosh17/go-sdk-template/apierror"
+)
+
+// RetryableFunc reports whether the error returned by an attempt is worth retrying.
+type RetryableFunc func(error) bool
+
+// RetryPolicySetter is implemented by retryers whose retry classification can be overridden.
+type RetryPolicySetter interface {
+	SetRetryable(fn RetryableFunc)
+}
+
+// IsRetryable is the default retry policy used by retryers of this package.
+//
+// Errors marked with MarkRetryable or MarkPermanent are classified as marked.
+// Context cancellation is never retried.
+// Throttling, server failures and network failures are retried whereas invalid requests and other API errors are not.
+// Errors which are unknown to the SDK are retried.
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var mark *retryMark
+	if errors.As(err, &mark) {
+		return mark.retryable
+	}
+
+	if isContextError(err) {
+		return false
+	}
+
+	var apiErr *apierror.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.ErrCode {
+		case apierror.ErrRequestThrottled.ErrCode, apierror.ErrInternalServer.ErrCode:
+			return true
+		case apierror.ErrSDK.ErrCode:
+			// SDK errors are mostly network failures unless the request was cancelled
+			return !isContextError(apiErr.Err)
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// MarkRetryable wraps the error so that IsRetryable always retries it.
+func MarkRetryable(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &retryMark{err: err, retryable: true}
+}
+
+// MarkPermanent wraps the error so that IsRetryable never retries it.
+func MarkPermanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &retryMark{err: err, retryable: false}
+}
+
+type retryMark struct {
+	err       error
+	retryable bool
+}
+
+func (m *retryMark) Error() string {
+	return m.err.Error()
+}
+
+func (m *retryMark) Unwrap() error {
+	return m.err
+}
+
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// retryable applies the given policy or falls back to IsRetryable.
+func retryable(fn RetryableFunc, err error) bool {
+	if fn == nil {
+		return IsRetryable(err)
+	}
+	return fn(err)
+}
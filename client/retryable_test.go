@@ -0,0 +1,68 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/nirdosh17/go-sdk-template/apierror"
+	"github.com/nirdosh17/go-sdk-template/test"
+)
+
+func TestIsRetryable(t *testing.T) {
+	netErr := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"nil", nil, false},
+		{"unknown error", errors.New("unknown"), true},
+		{"context cancelled", context.Canceled, false},
+		{"context deadline", fmt.Errorf("wrapped: %w", context.DeadlineExceeded), false},
+		{"throttled", apierror.New(apierror.ErrRequestThrottled.ErrCode, errors.New("slow down")), true},
+		{"server error", apierror.New(apierror.ErrInternalServer.ErrCode, errors.New("failed")), true},
+		{"network error", apierror.New(apierror.ErrSDK.ErrCode, fmt.Errorf("api request failure: %w", netErr)), true},
+		{"cancelled request", apierror.New(apierror.ErrSDK.ErrCode, fmt.Errorf("api request failure: %w", context.Canceled)), false},
+		{"invalid request", apierror.New(apierror.ErrInvalidRequestBody.ErrCode, errors.New("bad input")), false},
+		{"deserialization", apierror.New(apierror.ErrResponseDeserialization.ErrCode, errors.New("bad json")), false},
+		{"service specific error", apierror.New("INPUT_SIZE_EXCEEDED", errors.New("too large")), false},
+		{"marked retryable", MarkRetryable(apierror.New(apierror.ErrInvalidRequestBody.ErrCode, errors.New("bad input"))), true},
+		{"marked permanent", fmt.Errorf("wrapped: %w", MarkPermanent(errors.New("unknown"))), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test.ExpectEqual(t, "IsRetryable", tt.expected, IsRetryable(tt.err))
+		})
+	}
+}
+
+func TestRetry_Run_permanentError(t *testing.T) {
+	var counter int
+	r := &Retry{Delay: time.Millisecond, MaxRetries: 3}
+
+	err := r.Run(context.Background(), func(ctx context.Context) error {
+		counter++
+		return apierror.New(apierror.ErrInvalidRequestBody.ErrCode, errors.New("bad input"))
+	})
+
+	test.ExpectNotNil(t, "execError", err)
+	test.ExpectEqual(t, "function execution counter", 1, counter)
+}
+
+func TestRetry_Run_customPolicy(t *testing.T) {
+	var counter int
+	r := &Retry{Delay: time.Millisecond, MaxRetries: 3, Retryable: func(error) bool { return true }}
+
+	r.Run(context.Background(), func(ctx context.Context) error {
+		counter++
+		return apierror.New(apierror.ErrInvalidRequestBody.ErrCode, errors.New("bad input"))
+	})
+
+	test.ExpectEqual(t, "function execution counter", 3, counter)
+}
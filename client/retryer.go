@@ -9,6 +9,8 @@ const (
 	// DefaultRetryDelay is the time to wait between two retries.
 	DefaultRetryDelay = 2 * time.Second
 	DefaultMaxRetries = 3
+	// DefaultMaxRetryAfter caps the wait time advised by the server if the retryer sets no limit.
+	DefaultMaxRetryAfter = time.Minute
 )
 
 type Retryer interface {
@@ -16,11 +18,20 @@ type Retryer interface {
 	SetMaxRetries(n int)
 }
 
+// RetryerCloner is implemented by retryers which can be copied, so that copies of a config do not share retry settings.
+type RetryerCloner interface {
+	Clone() Retryer
+}
+
 type Retry struct {
 	// Delay is time to wait before until next retry
 	Delay time.Duration
 	// MaxRetries is the number attempts to try running given function.
 	MaxRetries int
+	// MaxRetryAfter caps the wait time advised by the server. Defaults to DefaultMaxRetryAfter.
+	MaxRetryAfter time.Duration
+	// Retryable decides which errors are retried. Defaults to IsRetryable.
+	Retryable RetryableFunc
 }
 
 func DefaultRetryer() *Retry {
@@ -28,6 +39,8 @@ func DefaultRetryer() *Retry {
 }
 
 // Run executes given function with constant backoff strategy. It uses a fixed delay window to wait after each retry and executes for 'n' times.
+// If the server has advised a wait time e.g. with Retry-After header, it is used instead of the fixed delay, capped at MaxRetryAfter.
+// The last error is returned without waiting if the advised wait time would exceed the context deadline.
 //
 // Example:
 //
@@ -46,18 +59,36 @@ func DefaultRetryer() *Retry {
 //		return fErr
 //	})
 func (r *Retry) Run(ctx context.Context, fn func(ctx context.Context) error) error {
-	var execErr error
+	var (
+		execErr error
+		delay   time.Duration
+	)
 	for i := 1; i <= r.MaxRetries; i++ {
-		execErr = fn(ctx)
-		if execErr == nil {
+		actx, span := StartAttemptSpan(ctx, i, delay)
+		execErr = fn(actx)
+		EndSpan(span, execErr)
+
+		// no need to wait after the last attempt
+		if execErr == nil || i == r.MaxRetries || !retryable(r.Retryable, execErr) {
+			break
+		}
+		// server advised wait time takes precedence over the fixed delay
+		delay = r.Delay
+		if d, ok := retryAfter(execErr); ok {
+			delay = capRetryAfter(d, r.MaxRetryAfter)
+			if exceedsDeadline(ctx, delay) {
+				break
+			}
+		}
+		// retries can be limited by a budget shared with other calls
+		if !takeRetry(ctx) {
 			break
 		}
 
-		timer := time.NewTimer(r.Delay)
-		if err := r.sleep(ctx, timer); err != nil {
+		logRetry(ctx, i, delay, execErr)
+		if err := sleep(ctx, delay); err != nil {
 			return err
 		}
-		timer.Stop()
 	}
 	return execErr
 }
@@ -69,17 +100,51 @@ func (r *Retry) SetMaxRetries(n int) {
 	}
 }
 
-func (r *Retry) sleep(ctx context.Context, timer *time.Timer) error {
+// SetRetryable overrides the policy which decides whether an error is retried.
+func (r *Retry) SetRetryable(fn RetryableFunc) {
+	r.Retryable = fn
+}
+
+// Clone returns a copy of the retryer.
+func (r *Retry) Clone() Retryer {
+	cp := *r
+	return &cp
+}
+
+// sleep waits for the given duration unless the context is cancelled earlier.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
 	select {
 	// timer sends message to channel when the time limit crosses
 	case <-timer.C:
 		return nil
 	case <-ctx.Done():
-		// context is cancelled, cleanup timer
-		timer.Stop()
 		return ctx.Err()
 	}
 }
 
+// capRetryAfter limits the wait time advised by the server to max, or to DefaultMaxRetryAfter if max is not set.
+func capRetryAfter(d, max time.Duration) time.Duration {
+	if max <= 0 {
+		max = DefaultMaxRetryAfter
+	}
+	if d > max {
+		return max
+	}
+	return d
+}
+
+// exceedsDeadline reports whether waiting for d would cross the deadline of the context.
+func exceedsDeadline(ctx context.Context, d time.Duration) bool {
+	deadline, ok := ctx.Deadline()
+	return ok && time.Until(deadline) < d
+}
+
 // to enforce compile type check
-var _ Retryer = (*Retry)(nil)
+var (
+	_ Retryer           = (*Retry)(nil)
+	_ RetryPolicySetter = (*Retry)(nil)
+	_ RetryerCloner     = (*Retry)(nil)
+)
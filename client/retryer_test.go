@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/nirdosh17/go-sdk-template/apierror"
 	"github.com/nirdosh17/go-sdk-template/test"
 )
 
@@ -39,7 +40,8 @@ func TestRetry_Run(t *testing.T) {
 			Delay:      200 * time.Millisecond,
 			MaxRetries: 3,
 		}
-		expectedExecDuration := r.Delay * time.Duration(r.MaxRetries)
+		// there is no delay after the last attempt
+		expectedExecDuration := r.Delay * time.Duration(r.MaxRetries-1)
 
 		execStart := time.Now()
 		err := r.Run(context.Background(), errfn)
@@ -54,9 +56,65 @@ func TestRetry_Run(t *testing.T) {
 	})
 }
 
+func TestRetry_Run_noDelayAfterLastAttempt(t *testing.T) {
+	r := &Retry{Delay: time.Second, MaxRetries: 1}
+
+	execStart := time.Now()
+	err := r.Run(context.Background(), func(ctx context.Context) error {
+		return errors.New("I will throw error!")
+	})
+
+	test.ExpectNotNil(t, "execError", err)
+	if d := time.Since(execStart); d >= r.Delay {
+		t.Errorf("expected no delay after last attempt but took %v", d)
+	}
+}
+
+func TestRetry_Run_retryAfterLimits(t *testing.T) {
+	var counter int
+	throttled := func(ctx context.Context) error {
+		counter++
+		return &apierror.APIError{ErrCode: apierror.ErrRequestThrottled.ErrCode, Err: apierror.ErrRequestThrottled.Err, RetryAfter: time.Hour}
+	}
+
+	t.Run("Capped", func(t *testing.T) {
+		counter = 0
+		r := &Retry{Delay: time.Second, MaxRetries: 2, MaxRetryAfter: time.Millisecond}
+
+		execStart := time.Now()
+		err := r.Run(context.Background(), throttled)
+		test.ExpectEqual(t, "throttled error", true, errors.Is(err, &apierror.ErrRequestThrottled))
+		test.ExpectEqual(t, "function execution counter", 2, counter)
+		if d := time.Since(execStart); d >= r.Delay {
+			t.Errorf("expected wait time to be capped at %v but took %v", r.MaxRetryAfter, d)
+		}
+	})
+
+	t.Run("ExceedsDeadline", func(t *testing.T) {
+		counter = 0
+		r := &Retry{Delay: time.Millisecond, MaxRetries: 3}
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		err := r.Run(ctx, throttled)
+		test.ExpectEqual(t, "throttled error", true, errors.Is(err, &apierror.ErrRequestThrottled))
+		test.ExpectEqual(t, "function execution counter", 1, counter)
+		test.ExpectNil(t, "context error", ctx.Err())
+	})
+}
+
 func Test_DefaultRetryer(t *testing.T) {
 	expected := Retry{Delay: DefaultRetryDelay, MaxRetries: DefaultMaxRetries}
 	if got := DefaultRetryer(); !reflect.DeepEqual(got, &expected) {
 		t.Errorf("DefaultRetryer() = %v, want %v", got, expected)
 	}
 }
+
+func TestRetry_Clone(t *testing.T) {
+	r := DefaultRetryer()
+	cp := r.Clone()
+	cp.SetMaxRetries(7)
+
+	test.ExpectEqual(t, "clone MaxRetries", 7, cp.(*Retry).MaxRetries)
+	test.ExpectEqual(t, "original MaxRetries", DefaultMaxRetries, r.MaxRetries)
+}
@@ -0,0 +1,127 @@
+package client
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strings"
+)
+
+// Event is a single message received from a `text/event-stream` response.
+type Event struct {
+	// ID is the value of the last "id" field seen on the stream.
+	ID string
+	// Event is the event type. Defaults to "message" when the server does not send one.
+	Event string
+	// Data contains all "data" lines of the event joined with a newline.
+	Data string
+}
+
+// EventStream decodes Server-Sent Events from a response body.
+//
+// Example:
+//
+//	stream := client.NewEventStream(resp.Body)
+//	defer stream.Close()
+//
+//	for stream.Next() {
+//		fmt.Println(stream.Event().Data)
+//	}
+//	if err := stream.Err(); err != nil {
+//		// handle err
+//	}
+type EventStream struct {
+	body   io.ReadCloser
+	reader *bufio.Reader
+	lastID string
+	event  Event
+	err    error
+}
+
+// NewEventStream returns a decoder which reads events from the given body. The body is closed by EventStream.Close.
+func NewEventStream(body io.ReadCloser) *EventStream {
+	return &EventStream{body: body, reader: bufio.NewReader(body)}
+}
+
+// Next advances the stream to the next event. It returns false when the stream ends or an error occurs.
+func (s *EventStream) Next() bool {
+	if s.err != nil {
+		return false
+	}
+
+	var (
+		eventType string
+		data      []string
+		hasData   bool
+	)
+
+	for {
+		line, err := s.reader.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			s.err = err
+			return false
+		}
+		if err != nil && line == "" {
+			// incomplete event at the end of stream is discarded as per the SSE spec
+			s.err = io.EOF
+			return false
+		}
+		line = strings.TrimRight(line, "\r\n")
+
+		// blank line dispatches the event
+		if line == "" {
+			if !hasData {
+				eventType = ""
+				continue
+			}
+			if eventType == "" {
+				eventType = "message"
+			}
+			s.event = Event{ID: s.lastID, Event: eventType, Data: strings.Join(data, "\n")}
+			return true
+		}
+
+		// lines starting with colon are comments, mostly used as keep-alive
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			eventType = value
+		case "data":
+			data = append(data, value)
+			hasData = true
+		case "id":
+			s.lastID = value
+		}
+
+		if errors.Is(err, io.EOF) {
+			s.err = io.EOF
+			return false
+		}
+	}
+}
+
+// Event returns the most recent event read by Next.
+func (s *EventStream) Event() Event {
+	return s.event
+}
+
+// Err returns the first non-EOF error encountered while reading the stream.
+func (s *EventStream) Err() error {
+	if errors.Is(s.err, io.EOF) {
+		return nil
+	}
+	return s.err
+}
+
+// Close closes the underlying response body.
+func (s *EventStream) Close() error {
+	if s.body == nil {
+		return nil
+	}
+	return s.body.Close()
+}
@@ -0,0 +1,66 @@
+package client
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/nirdosh17/go-sdk-template/apierror"
+	"github.com/nirdosh17/go-sdk-template/logger"
+	"github.com/nirdosh17/go-sdk-template/test"
+)
+
+func TestEventStream_Next(t *testing.T) {
+	body := ": keep-alive\n" +
+		"data: first\n\n" +
+		"id: 2\r\nevent: update\r\ndata: line one\r\ndata: line two\r\n\r\n" +
+		"event: empty\n\n" +
+		"data:no-space\n\n" +
+		"data: incomplete"
+	s := NewEventStream(io.NopCloser(strings.NewReader(body)))
+	defer s.Close()
+
+	var events []Event
+	for s.Next() {
+		events = append(events, s.Event())
+	}
+
+	test.ExpectNil(t, "EventStream.Err", s.Err())
+	test.ExpectEqual(t, "number of events", 3, len(events))
+	test.ExpectEqual(t, "events[0]", Event{Event: "message", Data: "first"}, events[0])
+	test.ExpectEqual(t, "events[1]", Event{ID: "2", Event: "update", Data: "line one\nline two"}, events[1])
+	test.ExpectEqual(t, "events[2]", Event{ID: "2", Event: "message", Data: "no-space"}, events[2])
+	test.ExpectEqual(t, "EventStream.Next after end", false, s.Next())
+}
+
+func TestRequest_PerformStream(t *testing.T) {
+	body := "data: {\"answer\":\"partial\"}\n\n"
+	mock := test.MockHTTPClient{
+		StatusCode: 200,
+		JSONBody:   &body,
+	}
+	r := Request{Client: &mock, Logger: logger.NewDefaultLogger(), Debug: true}
+
+	t.Run("success", func(t *testing.T) {
+		s, err := r.PerformStream(context.Background(), "http://api.doesnotmatter.com", "POST", nil)
+		test.ExpectNil(t, "Request.PerformStream", err)
+		defer s.Close()
+		test.ExpectEqual(t, "EventStream.Next", true, s.Next())
+		test.ExpectEqual(t, "Event.Data", `{"answer":"partial"}`, s.Event().Data)
+	})
+
+	t.Run("server failure", func(t *testing.T) {
+		mock.StatusCode = 503
+		_, err := r.PerformStream(context.Background(), "http://api.doesnotmatter.com", "POST", nil)
+		apiErr := err.(*apierror.APIError)
+		test.ExpectEqual(t, "Request.PerformStream", "INTERNAL_SERVER_ERROR", apiErr.ErrCode)
+	})
+
+	t.Run("req validation error", func(t *testing.T) {
+		mock.StatusCode = 400
+		_, err := r.PerformStream(context.Background(), "http://api.doesnotmatter.com", "POST", nil)
+		apiErr := err.(*apierror.APIError)
+		test.ExpectEqual(t, "Request.PerformStream", "INVALID_REQUEST_BODY", apiErr.ErrCode)
+	})
+}
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nirdosh17/go-sdk-template/apierror"
+)
+
+// Rate limit headers sent by the server. Reset is a unix timestamp in seconds.
+const (
+	headerRetryAfter         = "Retry-After"
+	headerRateLimitLimit     = "X-RateLimit-Limit"
+	headerRateLimitRemaining = "X-RateLimit-Remaining"
+	headerRateLimitReset     = "X-RateLimit-Reset"
+)
+
+// parseRetryAfter parses Retry-After header value which can either be delay in seconds or an HTTP date.
+func parseRetryAfter(v string, now time.Time) (time.Duration, bool) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+
+	t, err := http.ParseTime(v)
+	if err != nil {
+		return 0, false
+	}
+	d := t.Sub(now)
+	if d < 0 {
+		d = 0
+	}
+	return d, true
+}
+
+// parseRateLimit reads rate limit headers. It returns nil if server has not sent them.
+func parseRateLimit(h http.Header) *apierror.RateLimit {
+	limit, lErr := strconv.Atoi(h.Get(headerRateLimitLimit))
+	remaining, rErr := strconv.Atoi(h.Get(headerRateLimitRemaining))
+	reset, resetErr := strconv.ParseInt(h.Get(headerRateLimitReset), 10, 64)
+	if lErr != nil && rErr != nil && resetErr != nil {
+		return nil
+	}
+
+	rl := &apierror.RateLimit{Limit: limit, Remaining: remaining}
+	if resetErr == nil {
+		rl.Reset = time.Unix(reset, 0)
+	}
+	return rl
+}
+
+// retryAfter returns the wait time advised by the server for the given error.
+func retryAfter(err error) (time.Duration, bool) {
+	var apiErr *apierror.APIError
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+		return apiErr.RetryAfter, true
+	}
+	return 0, false
+}
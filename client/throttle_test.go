@@ -0,0 +1,89 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/nirdosh17/go-sdk-template/apierror"
+	"github.com/nirdosh17/go-sdk-template/logger"
+	"github.com/nirdosh17/go-sdk-template/model"
+	"github.com/nirdosh17/go-sdk-template/test"
+)
+
+func Test_parseRetryAfter(t *testing.T) {
+	now := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		{"empty", "", 0, false},
+		{"seconds", "120", 2 * time.Minute, true},
+		{"negative seconds", "-1", 0, false},
+		{"http date", "Sun, 01 Oct 2023 12:00:30 GMT", 30 * time.Second, true},
+		{"http date in past", "Sun, 01 Oct 2023 11:00:00 GMT", 0, true},
+		{"invalid", "soon", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, ok := parseRetryAfter(tt.value, now)
+			test.ExpectEqual(t, "parseRetryAfter ok", tt.ok, ok)
+			test.ExpectEqual(t, "parseRetryAfter duration", tt.expected, d)
+		})
+	}
+}
+
+func Test_parseRateLimit(t *testing.T) {
+	test.ExpectEqual(t, "parseRateLimit without headers", (*apierror.RateLimit)(nil), parseRateLimit(http.Header{}))
+
+	h := http.Header{}
+	h.Set("X-RateLimit-Limit", "100")
+	h.Set("X-RateLimit-Remaining", "0")
+	h.Set("X-RateLimit-Reset", "1696161600")
+	rl := parseRateLimit(h)
+	test.ExpectEqual(t, "RateLimit.Limit", 100, rl.Limit)
+	test.ExpectEqual(t, "RateLimit.Remaining", 0, rl.Remaining)
+	test.ExpectEqual(t, "RateLimit.Reset", int64(1696161600), rl.Reset.Unix())
+}
+
+func TestRequest_Perform_throttled(t *testing.T) {
+	json := `{"message": "slow down"}`
+	mock := test.MockHTTPClient{
+		StatusCode: 429,
+		JSONBody:   &json,
+		Header: http.Header{
+			"Retry-After":       []string{"3"},
+			"X-Ratelimit-Limit": []string{"10"},
+		},
+	}
+	r := Request{Client: &mock, Logger: logger.NewDefaultLogger()}
+
+	var a model.AIAnswer
+	err := r.Perform(context.Background(), "http://api.doesnotmatter.com", "POST", nil, &a)
+	apiErr := err.(*apierror.APIError)
+	test.ExpectEqual(t, "APIError.ErrCode", "TOO_MANY_REQUESTS", apiErr.ErrCode)
+	test.ExpectEqual(t, "APIError.RetryAfter", 3*time.Second, apiErr.RetryAfter)
+	test.ExpectEqual(t, "APIError.RateLimit.Limit", 10, apiErr.RateLimit.Limit)
+}
+
+func TestRetry_Run_retryAfter(t *testing.T) {
+	var counter int
+	r := &Retry{Delay: time.Minute, MaxRetries: 2}
+
+	execStart := time.Now()
+	err := r.Run(context.Background(), func(ctx context.Context) error {
+		counter++
+		return &apierror.APIError{ErrCode: apierror.ErrRequestThrottled.ErrCode, Err: apierror.ErrRequestThrottled.Err, RetryAfter: 10 * time.Millisecond}
+	})
+
+	test.ExpectNotNil(t, "execError", err)
+	test.ExpectEqual(t, "function execution counter", 2, counter)
+	if d := time.Since(execStart); d >= time.Minute {
+		t.Errorf("expected server advised delay to be used but took %v", d)
+	}
+}
@@ -0,0 +1,60 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// TracerName is the instrumentation name used for spans created by the sdk.
+const TracerName = "github.com/nirdosh17/go-sdk-template"
+
+type tracerKey struct{}
+
+// ContextWithTracer returns a copy of ctx which enables tracing of retry attempts with the given tracer.
+// Services add it to the context when tracing is configured.
+func ContextWithTracer(ctx context.Context, t trace.Tracer) context.Context {
+	return context.WithValue(ctx, tracerKey{}, t)
+}
+
+// StartAttemptSpan starts a span for a single attempt of a retryer. "attempt" starts from 1 and "delay" is the time
+// waited before the attempt. Returned context carries the attempt number, see AttemptFromContext.
+// Span is non-recording if tracing is not enabled in ctx.
+// Custom Retryer implementations can use it to produce the same traces and metrics as the retryers of this package.
+func StartAttemptSpan(ctx context.Context, attempt int, delay time.Duration) (context.Context, trace.Span) {
+	ctx = context.WithValue(ctx, attemptKey{}, attempt)
+
+	t, ok := ctx.Value(tracerKey{}).(trace.Tracer)
+	if !ok {
+		return ctx, noop.Span{}
+	}
+	return t.Start(ctx, "attempt",
+		trace.WithAttributes(
+			attribute.Int("retry.attempt", attempt),
+			attribute.Int64("retry.delay_ms", delay.Milliseconds()),
+		),
+	)
+}
+
+type attemptKey struct{}
+
+// AttemptFromContext returns the attempt number set by the retryer. It returns 1 if the request is not run by a retryer.
+func AttemptFromContext(ctx context.Context) int {
+	if n, ok := ctx.Value(attemptKey{}).(int); ok {
+		return n
+	}
+	return 1
+}
+
+// EndSpan records the error, if any, and ends the span.
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
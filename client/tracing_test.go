@@ -0,0 +1,80 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nirdosh17/go-sdk-template/logger"
+	"github.com/nirdosh17/go-sdk-template/test"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestRetry_Run_attemptSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	tracer := tp.Tracer(TracerName)
+
+	ctx, parent := tracer.Start(context.Background(), "parent")
+	ctx = ContextWithTracer(ctx, tracer)
+
+	var counter int
+	r := &Retry{Delay: 5 * time.Millisecond, MaxRetries: 3}
+	r.Run(ctx, func(ctx context.Context) error {
+		counter++
+		if counter < 3 {
+			return errors.New("I will throw error!")
+		}
+		return nil
+	})
+	parent.End()
+
+	spans := recorder.Ended()
+	test.ExpectEqual(t, "number of spans", 4, len(spans))
+
+	for i, s := range spans[:3] {
+		test.ExpectEqual(t, "span name", "attempt", s.Name())
+		test.ExpectEqual(t, "parent span", parent.SpanContext().SpanID(), s.Parent().SpanID())
+		attrs := attribute.NewSet(s.Attributes()...)
+		attempt, _ := attrs.Value("retry.attempt")
+		test.ExpectEqual(t, "retry.attempt", int64(i+1), attempt.AsInt64())
+		delay, _ := attrs.Value("retry.delay_ms")
+		expectedDelay := int64(5)
+		if i == 0 {
+			expectedDelay = 0
+		}
+		test.ExpectEqual(t, "retry.delay_ms", expectedDelay, delay.AsInt64())
+	}
+	test.ExpectEqual(t, "failed attempt status", codes.Error, spans[0].Status().Code)
+	test.ExpectEqual(t, "successful attempt status", codes.Unset, spans[2].Status().Code)
+}
+
+func TestStartAttemptSpan_withoutTracer(t *testing.T) {
+	ctx := context.Background()
+	test.ExpectEqual(t, "attempt without retryer", 1, AttemptFromContext(ctx))
+
+	actx, span := StartAttemptSpan(ctx, 2, 0)
+	test.ExpectEqual(t, "span.IsRecording", false, span.IsRecording())
+	test.ExpectEqual(t, "attempt", 2, AttemptFromContext(actx))
+}
+
+func TestRequest_Perform_traceContext(t *testing.T) {
+	tp := sdktrace.NewTracerProvider()
+	ctx, span := tp.Tracer(TracerName).Start(context.Background(), "parent")
+	defer span.End()
+
+	json := `{"answer": "answer from AI", "confidenceScore": 73}`
+	mock := test.MockHTTPClient{StatusCode: 200, JSONBody: &json}
+	r := Request{Client: &mock, Logger: logger.NewDefaultLogger(), Propagator: propagation.TraceContext{}}
+
+	err := r.Perform(ctx, "http://api.doesnotmatter.com", "POST", nil, nil)
+	test.ExpectNil(t, "Request.Perform", err)
+
+	expected := "00-" + span.SpanContext().TraceID().String() + "-" + span.SpanContext().SpanID().String() + "-01"
+	test.ExpectEqual(t, "traceparent header", expected, mock.LastRequest.Header.Get("traceparent"))
+}
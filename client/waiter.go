@@ -0,0 +1,86 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nirdosh17/go-sdk-template/apierror"
+)
+
+const (
+	// DefaultWaiterMinDelay is the wait time before the second poll of a Waiter.
+	DefaultWaiterMinDelay = time.Second
+	// DefaultWaiterMaxDelay caps the wait time between two polls of a Waiter.
+	DefaultWaiterMaxDelay = 30 * time.Second
+)
+
+// WaitFunc checks the state of a resource, e.g. a job. It returns true once the resource has reached its final state.
+// Returning an error stops waiting, e.g. when the resource has failed.
+type WaitFunc func(ctx context.Context) (done bool, err error)
+
+// Waiter polls a resource with exponentially growing delay until it is done, fails or the context is done.
+// Polls are spread with jitter so that many waiters do not poll in sync. It is safe for concurrent use.
+//
+// Each poll is a separate request with its own retries, so a failed poll stops waiting.
+type Waiter struct {
+	// MinDelay is the delay before the second poll. Defaults to DefaultWaiterMinDelay.
+	MinDelay time.Duration
+	// MaxDelay caps the delay between two polls. Defaults to DefaultWaiterMaxDelay.
+	MaxDelay time.Duration
+	// Multiplier is the factor by which the delay grows after each poll. Defaults to DefaultMultiplier.
+	Multiplier float64
+}
+
+// NewWaiter returns a waiter with default settings.
+func NewWaiter() *Waiter {
+	return &Waiter{MinDelay: DefaultWaiterMinDelay, MaxDelay: DefaultWaiterMaxDelay, Multiplier: DefaultMultiplier}
+}
+
+// Wait calls check right away and then after each delay until check reports done or returns an error.
+// Waiting is bounded by the context, e.g. with context.WithTimeout. The returned error wraps the context error
+// if the context is done before the resource.
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+//	defer cancel()
+//
+//	err := client.NewWaiter().Wait(ctx, func(ctx context.Context) (bool, error) {
+//		job, err := getJob(ctx, id)
+//		return job.Done(), err
+//	})
+func (w *Waiter) Wait(ctx context.Context, check WaitFunc) error {
+	retry := &ExponentialRetry{
+		BaseDelay:  w.MinDelay,
+		Multiplier: w.Multiplier,
+		MaxDelay:   w.MaxDelay,
+		Jitter:     EqualJitter,
+	}
+	if retry.BaseDelay <= 0 {
+		retry.BaseDelay = DefaultWaiterMinDelay
+	}
+	if retry.MaxDelay <= 0 {
+		retry.MaxDelay = DefaultWaiterMaxDelay
+	}
+	if retry.Multiplier <= 0 {
+		retry.Multiplier = DefaultMultiplier
+	}
+
+	var delay time.Duration
+	for poll := 1; ; poll++ {
+		if err := ctx.Err(); err != nil {
+			return apierror.ErrSDK.Record(fmt.Errorf("waiter: %w", err))
+		}
+
+		done, err := check(ctx)
+		if err != nil || done {
+			return err
+		}
+
+		delay = retry.delay(poll, delay)
+		if err := sleep(ctx, delay); err != nil {
+			return apierror.ErrSDK.Record(fmt.Errorf("waiter: %w", err))
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nirdosh17/go-sdk-template/apierror"
+	"github.com/nirdosh17/go-sdk-template/test"
+)
+
+func TestWaiter_Wait(t *testing.T) {
+	w := &Waiter{MinDelay: time.Millisecond, MaxDelay: 4 * time.Millisecond}
+
+	polls := 0
+	err := w.Wait(context.Background(), func(ctx context.Context) (bool, error) {
+		polls++
+		return polls == 3, nil
+	})
+	test.ExpectNil(t, "Wait", err)
+	test.ExpectEqual(t, "polls", 3, polls)
+}
+
+func TestWaiter_Wait_checkError(t *testing.T) {
+	w := &Waiter{MinDelay: time.Millisecond}
+	failed := errors.New("job failed")
+
+	polls := 0
+	err := w.Wait(context.Background(), func(ctx context.Context) (bool, error) {
+		polls++
+		return false, failed
+	})
+	test.ExpectEqual(t, "error", failed, err)
+	test.ExpectEqual(t, "polls", 1, polls)
+}
+
+func TestWaiter_Wait_deadline(t *testing.T) {
+	w := &Waiter{MinDelay: 5 * time.Millisecond, MaxDelay: 5 * time.Millisecond}
+	ctx, cancel := context.WithTimeout(context.Background(), 12*time.Millisecond)
+	defer cancel()
+
+	polls := 0
+	start := time.Now()
+	err := w.Wait(ctx, func(ctx context.Context) (bool, error) {
+		polls++
+		return false, nil
+	})
+	test.ExpectEqual(t, "sdk error", true, errors.Is(err, &apierror.ErrSDK))
+	test.ExpectEqual(t, "deadline exceeded", true, errors.Is(err, context.DeadlineExceeded))
+	test.ExpectEqual(t, "stopped at deadline", true, time.Since(start) < time.Second)
+	test.ExpectEqual(t, "polled more than once", true, polls > 1)
+}
+
+func TestWaiter_Wait_backoff(t *testing.T) {
+	w := &Waiter{MinDelay: 2 * time.Millisecond, MaxDelay: 8 * time.Millisecond, Multiplier: 2}
+
+	var polls []time.Time
+	w.Wait(context.Background(), func(ctx context.Context) (bool, error) {
+		polls = append(polls, time.Now())
+		return len(polls) == 6, nil
+	})
+
+	// equal jitter waits at least half of the delay: 1, 2, 4, 4, 4 ms
+	test.ExpectEqual(t, "total wait", true, polls[5].Sub(polls[0]) >= 15*time.Millisecond)
+}
@@ -2,8 +2,15 @@
 package config
 
 import (
+	"net/http"
+	"time"
+
 	"github.com/nirdosh17/go-sdk-template/client"
+	"github.com/nirdosh17/go-sdk-template/credentials"
 	"github.com/nirdosh17/go-sdk-template/logger"
+	"github.com/nirdosh17/go-sdk-template/metrics"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -13,24 +20,53 @@ const (
 type Config struct {
 	// APIKey is required to make authenticated requests to the server. Generate APIKey from developer settings.
 	APIKey string
+	// Credentials provides the API key for each request, allowing keys to be rotated without restarting the application.
+	// Takes precedence over APIKey. See credentials package.
+	Credentials credentials.Provider
 	// Endpoint is optional URL that overrides default service endpoint.
 	// Some services offer regional endpoints which you can choose based on proximity for minimal latency.
 	Endpoint string
+	// Regions replaces Endpoint with regional endpoints selected by latency and health, with failover to the next region
+	// on connection errors and 5xx server errors. Disabled if nil.
+	Regions *client.RegionalEndpoints
 	// HTTP client to use while sending requests. Defaults to `http.DefaultClient`
 	HTTPClient client.HTTPClient
+	// Headers are added to every request, e.g. to identify the calling application.
+	Headers http.Header
+	// Timeout limits the duration of each api call including retries. Streams are limited by context only. No limit if zero.
+	Timeout time.Duration
 	// Retryer function
 	Retryer client.Retryer
 	// The maximum number of times a request will be retried before it is considered failed. Defaults to 3.
 	MaxRetries int
+	// RetryPolicy decides which errors are retried. Defaults to `client.IsRetryable`
+	RetryPolicy client.RetryableFunc
 	// logger function for sdk
 	Logger logger.Logger
 	// Debug enables verbose logging if set to true
 	Debug bool
+	// Interceptors are called around every request sent by the services. See client.Interceptor.
+	Interceptors []client.Interceptor
+	// TracerProvider enables OpenTelemetry tracing of api calls and retry attempts. Tracing is disabled if nil.
+	TracerProvider trace.TracerProvider
+	// Propagator injects trace context into request headers when tracing is enabled. Defaults to W3C trace context
+	// if nil while TracerProvider is set.
+	Propagator propagation.TextMapPropagator
+	// Metrics collects request counts, latency, retries and throttling events. Disabled if nil.
+	Metrics metrics.Collector
+	// CircuitBreaker fails requests fast while the server is unavailable. Disabled if nil.
+	CircuitBreaker *client.CircuitBreaker
+	// RateLimiter delays requests of all services sharing the config to keep their rate under a limit. Disabled if nil.
+	RateLimiter client.RateLimiter
+	// Redaction rules applied to http dumps in debug mode. Defaults to `client.DefaultRedaction` if nil.
+	Redaction *client.Redaction
 }
 
 // NewConfig return a instance of config with default settings.
+// An empty apiKey must be supplied with WithAPIKey or WithCredentials, otherwise Validate reports it as missing.
+// Use LoadDefaultConfig to read it from the environment variable CHATAI_API_KEY or the shared credentials file.
 func NewConfig(apiKey string) *Config {
-	return &Config{
+	c := &Config{
 		APIKey:     apiKey,
 		HTTPClient: client.DefaultClient(),
 		Retryer:    client.DefaultRetryer(),
@@ -38,11 +74,30 @@ func NewConfig(apiKey string) *Config {
 		Logger:     logger.NewDefaultLogger(),
 		Debug:      false,
 	}
+	return c
 }
 
-// WithAPIKey is used to configure the API key.
+// WithAPIKey is used to configure the API key. It replaces credentials provider, if any.
 func (c *Config) WithAPIKey(k string) *Config {
 	c.APIKey = k
+	c.Credentials = nil
+	return c
+}
+
+// WithCredentials retrieves the API key from the given provider. Credentials are cached until they expire
+// unless the provider already implements credentials.Invalidator.
+//
+// Example:
+//
+//	c := config.NewConfig("").WithCredentials(credentials.NewChain(
+//		credentials.EnvProvider{},
+//		credentials.FileProvider{Profile: "production"},
+//	))
+func (c *Config) WithCredentials(p credentials.Provider) *Config {
+	if _, ok := p.(credentials.Invalidator); !ok {
+		p = credentials.NewCache(p)
+	}
+	c.Credentials = p
 	return c
 }
 
@@ -52,9 +107,39 @@ func (c *Config) WithHTTPClient(hc client.Client) *Config {
 	return c
 }
 
-// WithEndpoint overrides default endpoint.
+// WithEndpoint overrides default endpoint. It disables regional endpoints, if any.
 func (c *Config) WithEndpoint(endpoint string) *Config {
 	c.Endpoint = endpoint
+	c.Regions = nil
+	return c
+}
+
+// WithRegions sends requests to the given regions instead of Endpoint. Regions are listed in order of preference.
+// Requests go to the region with the lowest latency and fail over to the next region if a region is unavailable.
+//
+// Example:
+//
+//	c := config.NewConfig("apiKey").WithRegions(
+//		client.EndpointTemplate("https://{region}.api.chatai.com"),
+//		"eu-west", "us-east", "ap-south",
+//	)
+func (c *Config) WithRegions(resolver client.EndpointResolver, regions ...string) *Config {
+	c.Regions = client.NewRegionalEndpoints(resolver, regions...)
+	return c
+}
+
+// WithHeader adds a header which is sent with every request.
+func (c *Config) WithHeader(key, value string) *Config {
+	if c.Headers == nil {
+		c.Headers = http.Header{}
+	}
+	c.Headers.Add(key, value)
+	return c
+}
+
+// WithTimeout limits the duration of each api call including retries. Use WithHTTPClient to limit each attempt.
+func (c *Config) WithTimeout(d time.Duration) *Config {
+	c.Timeout = d
 	return c
 }
 
@@ -64,22 +149,131 @@ func (c *Config) WithRetryer(r client.Retryer) *Config {
 	return c
 }
 
+// WithExponentialBackoff replaces the default fixed delay retryer with client.ExponentialRetry using full jitter.
+// Use WithRetryer to pass an ExponentialRetry with custom delays and jitter mode.
+func (c *Config) WithExponentialBackoff() *Config {
+	r := client.DefaultExponentialRetryer()
+	r.SetMaxRetries(c.MaxRetries)
+	r.SetRetryable(c.RetryPolicy)
+	c.Retryer = r
+	return c
+}
+
 // WithMaxRetries overrides default max retry count of default retryer.
+// Retryers implementing client.RetryerCloner are copied first, so configs sharing the retryer are not affected.
+// Other custom retryers are changed in place.
 func (c *Config) WithMaxRetries(n int) *Config {
 	if n > 0 {
 		// TODO: save max retries in single place
 		c.MaxRetries = n
+		if r, ok := c.Retryer.(client.RetryerCloner); ok {
+			c.Retryer = r.Clone()
+		}
 		c.Retryer.SetMaxRetries(n)
 	}
 	return c
 }
 
-// WithLogger overrides default logger.
+// WithRetryPolicy overrides which errors are retried by the retryer.
+// It has no effect on custom retryers which do not implement client.RetryPolicySetter.
+// Retryers implementing client.RetryerCloner are copied first, so configs sharing the retryer are not affected.
+//
+// Example:
+//
+//	c := config.NewConfig("apiKey").WithRetryPolicy(func(err error) bool {
+//		return errors.Is(err, errTemporary) || client.IsRetryable(err)
+//	})
+func (c *Config) WithRetryPolicy(fn client.RetryableFunc) *Config {
+	c.RetryPolicy = fn
+	if r, ok := c.Retryer.(client.RetryerCloner); ok {
+		c.Retryer = r.Clone()
+	}
+	if s, ok := c.Retryer.(client.RetryPolicySetter); ok {
+		s.SetRetryable(fn)
+	}
+	return c
+}
+
+// WithLogger overrides default logger. Loggers implementing logger.StructuredLogger receive leveled entries with key-value fields.
 func (c *Config) WithLogger(logger logger.Logger) *Config {
 	c.Logger = logger
 	return c
 }
 
+// WithInterceptors appends interceptors to the chain which is run around every request.
+// Interceptors are run in the order they are added.
+func (c *Config) WithInterceptors(i ...client.Interceptor) *Config {
+	c.Interceptors = append(c.Interceptors, i...)
+	return c
+}
+
+// WithTracerProvider enables OpenTelemetry tracing using the given provider.
+// Each api call creates a span with a child span for every retry attempt, and W3C trace context headers are sent to the server.
+//
+// Example:
+//
+//	c := config.NewConfig("apiKey").WithTracerProvider(otel.GetTracerProvider())
+func (c *Config) WithTracerProvider(tp trace.TracerProvider) *Config {
+	c.TracerProvider = tp
+	if c.Propagator == nil {
+		c.Propagator = propagation.TraceContext{}
+	}
+	return c
+}
+
+// WithMetrics enables collection of request metrics. See metrics package for expvar and Prometheus collectors.
+func (c *Config) WithMetrics(m metrics.Collector) *Config {
+	c.Metrics = m
+	return c
+}
+
+// WithCircuitBreaker stops sending requests while the server is unavailable. Calls fail fast with apierror.ErrCircuitOpen
+// instead of waiting for all retries to fail.
+//
+// Example:
+//
+//	cb := client.NewCircuitBreaker()
+//	cb.OnStateChange = func(from, to client.CircuitState) {
+//		log.Printf("chatai circuit %s -> %s", from, to)
+//	}
+//	c := config.NewConfig("apiKey").WithCircuitBreaker(cb)
+func (c *Config) WithCircuitBreaker(cb *client.CircuitBreaker) *Config {
+	c.CircuitBreaker = cb
+	return c
+}
+
+// WithRateLimit limits requests of all services to rps requests per second with bursts of up to burst requests.
+// The rate is lowered temporarily when the server throttles requests. Use WithRateLimiter for per service rates.
+func (c *Config) WithRateLimit(rps float64, burst int) *Config {
+	c.RateLimiter = client.NewTokenBucketLimiter(rps, burst)
+	return c
+}
+
+// WithRateLimiter sets the limiter which delays requests before they are sent.
+//
+// Example:
+//
+//	// 10 requests/sec in total, of which chatai can send up to 2
+//	c := config.NewConfig("apiKey").WithRateLimiter(
+//		client.NewTokenBucketLimiter(10, 5).WithService("chatai", 2, 1),
+//	)
+func (c *Config) WithRateLimiter(l client.RateLimiter) *Config {
+	c.RateLimiter = l
+	return c
+}
+
+// WithRedaction overrides which headers and JSON fields are hidden from http dumps in debug mode.
+//
+// Example:
+//
+//	r := client.DefaultRedaction()
+//	r.JSONFields = append(r.JSONFields, "answer")
+//	c := config.NewConfig("apiKey").WithDebugEnabled().WithRedaction(r)
+func (c *Config) WithRedaction(r client.Redaction) *Config {
+	c.Redaction = &r
+	return c
+}
+
 // WithDebugEnabled enables debug flag which for verbose logging.
 func (c *Config) WithDebugEnabled() *Config {
 	c.Debug = true
@@ -1,12 +1,22 @@
 package config
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/nirdosh17/go-sdk-template/client"
+	"github.com/nirdosh17/go-sdk-template/credentials"
+	"github.com/nirdosh17/go-sdk-template/logger"
+	"github.com/nirdosh17/go-sdk-template/metrics"
 	"github.com/nirdosh17/go-sdk-template/test"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
 )
 
 func TestConfig_NewConfig(t *testing.T) {
@@ -31,12 +41,43 @@ func TestConfig_WithEndpoint(t *testing.T) {
 	test.ExpectEqual(t, "Endpoint", config.Endpoint, e)
 }
 
+func TestConfig_WithTimeout(t *testing.T) {
+	config := NewConfig("apiKey").WithTimeout(time.Minute)
+	test.ExpectEqual(t, "Timeout", time.Minute, config.Timeout)
+}
+
+func TestConfig_WithRegions(t *testing.T) {
+	config := NewConfig("apiKey").WithRegions(client.EndpointTemplate("https://{region}.chatai.com"), "eu", "us")
+	test.ExpectEqual(t, "Regions", "eu,us", strings.Join(config.Regions.Regions, ","))
+	test.ExpectNil(t, "Validate", config.Validate())
+
+	config.WithEndpoint("https://chatai.com")
+	test.ExpectEqual(t, "Regions disabled by WithEndpoint", true, config.Regions == nil)
+}
+
 func TestConfig_WithAPIKey(t *testing.T) {
 	config := &Config{}
 	config.WithAPIKey("test-key")
 	test.ExpectEqual(t, "APIKey", config.APIKey, "test-key")
 }
 
+func TestConfig_WithAPIKey_replacesCredentials(t *testing.T) {
+	config := NewConfig("").WithCredentials(credentials.EnvProvider{})
+	test.ExpectNotNil(t, "credentials", config.Credentials)
+
+	config.WithAPIKey("test-key")
+	test.ExpectNil(t, "Credentials", config.Credentials)
+}
+
+func TestConfig_WithCredentials(t *testing.T) {
+	config := NewConfig("apiKey").WithCredentials(credentials.StaticProvider{APIKey: "test-key"})
+	test.ExpectSameType(t, "Credentials", &credentials.Cache{}, config.Credentials)
+
+	cache := credentials.NewCache(credentials.EnvProvider{})
+	config.WithCredentials(cache)
+	test.ExpectEqual(t, "Credentials", credentials.Provider(cache), config.Credentials)
+}
+
 type mockRetry struct {
 	MaxRetries int
 }
@@ -65,6 +106,45 @@ func TestConfig_WithMaxRetries(t *testing.T) {
 	test.ExpectEqual(t, "Config.MaxRetries", 2, config.MaxRetries)
 }
 
+func TestConfig_WithMaxRetries_sharedRetryer(t *testing.T) {
+	config := NewConfig("apiKey")
+	shared := config.Retryer.(*client.Retry)
+
+	other := *config
+	other.WithMaxRetries(7)
+	test.ExpectEqual(t, "shared Retryer.MaxRetries", client.DefaultMaxRetries, shared.MaxRetries)
+	test.ExpectEqual(t, "copied Retryer.MaxRetries", 7, other.Retryer.(*client.Retry).MaxRetries)
+}
+
+func TestConfig_WithExponentialBackoff(t *testing.T) {
+	config := NewConfig("apiKey").WithMaxRetries(5).WithExponentialBackoff()
+	r, ok := config.Retryer.(*client.ExponentialRetry)
+	test.ExpectEqual(t, "Retryer is ExponentialRetry", true, ok)
+	test.ExpectEqual(t, "Retryer.MaxRetries", 5, r.MaxRetries)
+	test.ExpectEqual(t, "Retryer.Jitter", client.FullJitter, r.Jitter)
+}
+
+func TestConfig_WithRetryPolicy(t *testing.T) {
+	never := func(error) bool { return false }
+	config := NewConfig("apiKey").WithRetryPolicy(never)
+	r := config.Retryer.(*client.Retry)
+	test.ExpectEqual(t, "Retryer.Retryable", false, r.Retryable(errors.New("any error")))
+
+	config.WithExponentialBackoff()
+	er := config.Retryer.(*client.ExponentialRetry)
+	test.ExpectEqual(t, "ExponentialRetry.Retryable", false, er.Retryable(errors.New("any error")))
+}
+
+func TestConfig_WithRetryPolicy_sharedRetryer(t *testing.T) {
+	config := NewConfig("apiKey")
+	shared := config.Retryer.(*client.Retry)
+
+	other := *config
+	other.WithRetryPolicy(func(error) bool { return false })
+	test.ExpectEqual(t, "shared Retryer.Retryable", true, shared.Retryable == nil)
+	test.ExpectEqual(t, "copied Retryer.Retryable", false, other.Retryer.(*client.Retry).Retryable(errors.New("any error")))
+}
+
 type mockLogger struct {
 }
 
@@ -78,6 +158,44 @@ func TestConfig_WithLogger(t *testing.T) {
 	test.ExpectSameType(t, "Logger", l, config.Logger)
 }
 
+func TestConfig_WithInterceptors(t *testing.T) {
+	first, second := client.InterceptorFuncs{}, client.InterceptorFuncs{}
+	config := NewConfig("apiKey").WithInterceptors(first).WithInterceptors(second)
+	test.ExpectEqual(t, "len(Interceptors)", 2, len(config.Interceptors))
+}
+
+func TestConfig_WithTracerProvider(t *testing.T) {
+	config := NewConfig("apiKey")
+	test.ExpectNil(t, "Propagator", config.Propagator)
+
+	tp := noop.NewTracerProvider()
+	config.WithTracerProvider(tp)
+	test.ExpectEqual(t, "TracerProvider", trace.TracerProvider(tp), config.TracerProvider)
+	test.ExpectSameType(t, "Propagator", propagation.TraceContext{}, config.Propagator)
+}
+
+func TestConfig_WithMetrics(t *testing.T) {
+	m := metrics.NewExpvarCollector("config_test_metrics")
+	config := NewConfig("apiKey").WithMetrics(m)
+	test.ExpectEqual(t, "Metrics", metrics.Collector(m), config.Metrics)
+}
+
+func TestConfig_WithRateLimit(t *testing.T) {
+	config := NewConfig("apiKey").WithRateLimit(10, 5)
+	l := config.RateLimiter.(*client.TokenBucketLimiter)
+	test.ExpectEqual(t, "RPS", 10.0, l.Rate.RPS)
+	test.ExpectEqual(t, "Burst", 5, l.Rate.Burst)
+
+	custom := client.NewTokenBucketLimiter(1, 1)
+	config.WithRateLimiter(custom)
+	test.ExpectEqual(t, "RateLimiter", client.RateLimiter(custom), config.RateLimiter)
+}
+
+func TestConfig_WithRedaction(t *testing.T) {
+	config := NewConfig("apiKey").WithRedaction(client.Redaction{MetadataOnly: true})
+	test.ExpectEqual(t, "Redaction.MetadataOnly", true, config.Redaction.MetadataOnly)
+}
+
 func TestConfig_WithDebugEnabled(t *testing.T) {
 	config := NewConfig("apiKey")
 	test.ExpectEqual(t, "config.Debug", false, config.Debug)
@@ -85,3 +203,28 @@ func TestConfig_WithDebugEnabled(t *testing.T) {
 	config.WithDebugEnabled()
 	test.ExpectEqual(t, "config.Debug", true, config.Debug)
 }
+
+func TestConfig_WithDebugEnabled_defaultLogger(t *testing.T) {
+	var buf bytes.Buffer
+	config := NewConfig("apiKey").WithDebugEnabled()
+	config.Logger.(*logger.SimpleLogger).Logger.SetOutput(&buf)
+
+	json := `{"answer": "yes"}`
+	r := client.Request{Client: &test.MockHTTPClient{StatusCode: 200, JSONBody: &json}, APIKey: config.APIKey, Logger: config.Logger, Debug: config.Debug}
+	err := r.Perform(context.Background(), "http://api.doesnotmatter.com", "POST", nil, nil)
+	test.ExpectNil(t, "Perform", err)
+
+	test.ExpectEqual(t, "request dump", true, strings.Contains(buf.String(), "HTTP request dump"))
+	test.ExpectEqual(t, "response dump", true, strings.Contains(buf.String(), "HTTP response dump"))
+}
+
+func TestConfig_WithHeader(t *testing.T) {
+	config := NewConfig("apiKey").WithHeader("X-Team", "search").WithHeader("X-Team", "ads")
+	test.ExpectEqual(t, "Headers", 2, len(config.Headers.Values("X-Team")))
+}
+
+func TestConfig_WithCircuitBreaker(t *testing.T) {
+	cb := client.NewCircuitBreaker()
+	config := NewConfig("apiKey").WithCircuitBreaker(cb)
+	test.ExpectEqual(t, "CircuitBreaker", cb, config.CircuitBreaker)
+}
@@ -16,3 +16,14 @@ func Example() {
 	s := chatai.NewService(c)
 	s.AskAI("some question")
 }
+
+func ExampleLoadDefaultConfig() {
+	// reads CHATAI_* environment variables and the "staging" profile of ~/.chatai/config
+	c, err := config.LoadDefaultConfig(config.WithProfile("staging"))
+	if err != nil {
+		return
+	}
+
+	s := chatai.NewService(c.WithMaxRetries(5))
+	s.AskAI("some question")
+}
@@ -0,0 +1,265 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nirdosh17/go-sdk-template/apierror"
+	"github.com/nirdosh17/go-sdk-template/credentials"
+	"gopkg.in/yaml.v3"
+)
+
+// Environment variables read by LoadDefaultConfig.
+const (
+	EnvAPIKey      = credentials.EnvAPIKey
+	EnvEndpoint    = "CHATAI_ENDPOINT"
+	EnvMaxRetries  = "CHATAI_MAX_RETRIES"
+	EnvDebug       = "CHATAI_DEBUG"
+	EnvTimeout     = "CHATAI_TIMEOUT"
+	EnvHTTPTimeout = "CHATAI_HTTP_TIMEOUT"
+	EnvConfigFile  = "CHATAI_CONFIG_FILE"
+	EnvProfile     = credentials.EnvProfile
+)
+
+// loadOptions are the options of LoadDefaultConfig.
+type loadOptions struct {
+	configFile string
+	profile    string
+	lookupEnv  func(string) (string, bool)
+}
+
+// LoadOption configures LoadDefaultConfig.
+type LoadOption func(*loadOptions)
+
+// WithConfigFile reads settings from the given JSON or YAML file instead of CHATAI_CONFIG_FILE or ~/.chatai/config.
+// Loading fails if the file does not exist.
+func WithConfigFile(filename string) LoadOption {
+	return func(o *loadOptions) {
+		o.configFile = filename
+	}
+}
+
+// WithProfile selects the profile of the config file and the shared credentials file instead of CHATAI_PROFILE.
+// Loading fails if the config file has no such profile.
+func WithProfile(name string) LoadOption {
+	return func(o *loadOptions) {
+		o.profile = name
+	}
+}
+
+// WithEnvLookup replaces os.LookupEnv for reading environment variables, e.g. to read them with a prefix or in tests.
+func WithEnvLookup(fn func(string) (string, bool)) LoadOption {
+	return func(o *loadOptions) {
+		o.lookupEnv = fn
+	}
+}
+
+// fileProfile contains the settings of a profile in the config file. Timeouts are formatted like "1m30s".
+type fileProfile struct {
+	APIKey      string `json:"apiKey" yaml:"apiKey"`
+	Endpoint    string `json:"endpoint" yaml:"endpoint"`
+	MaxRetries  int    `json:"maxRetries" yaml:"maxRetries"`
+	Debug       *bool  `json:"debug" yaml:"debug"`
+	Timeout     string `json:"timeout" yaml:"timeout"`
+	HTTPTimeout string `json:"httpTimeout" yaml:"httpTimeout"`
+}
+
+// configFile is the content of the config file.
+type configFile struct {
+	Profiles map[string]fileProfile `json:"profiles" yaml:"profiles"`
+}
+
+// LoadDefaultConfig returns a config with default settings overridden by the selected profile of the config file,
+// which are in turn overridden by environment variables. Explicit With* calls on the returned config take precedence over both.
+//
+// The config file is read from CHATAI_CONFIG_FILE or ~/.chatai/config if it exists. Files with .json extension are parsed as JSON,
+// others as YAML:
+//
+//	profiles:
+//	  default:
+//	    endpoint: https://api.chatai.com
+//	    maxRetries: 3
+//	  staging:
+//	    endpoint: https://staging.chatai.com
+//	    debug: true
+//	    timeout: 30s
+//	    httpTimeout: 10s
+//
+// Supported environment variables are CHATAI_API_KEY, CHATAI_ENDPOINT, CHATAI_MAX_RETRIES, CHATAI_DEBUG, CHATAI_TIMEOUT,
+// CHATAI_HTTP_TIMEOUT, CHATAI_CONFIG_FILE and CHATAI_PROFILE. API key is retrieved from the shared credentials file
+// if it is set by neither of them. Loaded config is not validated, so it can be completed with With* calls
+// before it is checked with Validate or by the first request.
+//
+// Example:
+//
+//	c, err := config.LoadDefaultConfig(config.WithProfile("staging"))
+//	if err != nil {
+//		return err
+//	}
+//	ai := chatai.NewService(c.WithLogger(myLogger))
+func LoadDefaultConfig(opts ...LoadOption) (*Config, error) {
+	o := loadOptions{lookupEnv: os.LookupEnv}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	profile := o.profile
+	if profile == "" {
+		profile, _ = o.lookupEnv(EnvProfile)
+	}
+
+	c := NewConfig("").WithCredentials(credentials.NewChain(
+		credentials.EnvProvider{LookupEnv: o.lookupEnv},
+		credentials.FileProvider{Profile: profile, LookupEnv: o.lookupEnv},
+	))
+
+	p, err := o.readProfile(profile)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.applyProfile(p); err != nil {
+		return nil, err
+	}
+	if err := c.applyEnv(o.lookupEnv); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// readProfile returns the profile of the config file. Empty profile is returned if there is no config file.
+func (o loadOptions) readProfile(profile string) (fileProfile, error) {
+	filename, required := o.configFile, true
+	if filename == "" {
+		filename, _ = o.lookupEnv(EnvConfigFile)
+	}
+	if filename == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return fileProfile{}, nil
+		}
+		filename, required = filepath.Join(home, ".chatai", "config"), false
+	}
+
+	data, err := os.ReadFile(filename)
+	if os.IsNotExist(err) && !required {
+		return fileProfile{}, nil
+	}
+	if err != nil {
+		return fileProfile{}, loadError(fmt.Errorf("failed reading config file: %w", err))
+	}
+
+	var f configFile
+	if strings.EqualFold(filepath.Ext(filename), ".json") {
+		err = json.Unmarshal(data, &f)
+	} else {
+		err = yaml.Unmarshal(data, &f)
+	}
+	if err != nil {
+		return fileProfile{}, loadError(fmt.Errorf("invalid config file %s: %w", filename, err))
+	}
+
+	name := profile
+	if name == "" {
+		name = credentials.DefaultProfile
+	}
+	p, ok := f.Profiles[name]
+	if !ok && profile != "" {
+		return fileProfile{}, loadError(fmt.Errorf("profile %q not found in %s", profile, filename))
+	}
+	return p, nil
+}
+
+// applyProfile overrides the settings set in the profile.
+func (c *Config) applyProfile(p fileProfile) error {
+	if p.APIKey != "" {
+		c.WithAPIKey(p.APIKey)
+	}
+	if p.Endpoint != "" {
+		c.WithEndpoint(p.Endpoint)
+	}
+	if p.MaxRetries > 0 {
+		c.WithMaxRetries(p.MaxRetries)
+	}
+	if p.Debug != nil {
+		c.Debug = *p.Debug
+	}
+	if err := c.applyTimeout(p.Timeout, "timeout"); err != nil {
+		return err
+	}
+	return c.applyHTTPTimeout(p.HTTPTimeout, "httpTimeout")
+}
+
+// applyEnv overrides the settings set in environment variables.
+func (c *Config) applyEnv(lookupEnv func(string) (string, bool)) error {
+	if v, ok := lookupEnv(EnvAPIKey); ok && v != "" {
+		c.WithAPIKey(v)
+	}
+	if v, ok := lookupEnv(EnvEndpoint); ok && v != "" {
+		c.WithEndpoint(v)
+	}
+	if v, ok := lookupEnv(EnvMaxRetries); ok && v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return loadError(fmt.Errorf("invalid %s %q: must be a positive integer", EnvMaxRetries, v))
+		}
+		c.WithMaxRetries(n)
+	}
+	if v, ok := lookupEnv(EnvDebug); ok && v != "" {
+		debug, err := strconv.ParseBool(v)
+		if err != nil {
+			return loadError(fmt.Errorf("invalid %s %q: %w", EnvDebug, v, err))
+		}
+		c.Debug = debug
+	}
+	if v, ok := lookupEnv(EnvTimeout); ok {
+		if err := c.applyTimeout(v, EnvTimeout); err != nil {
+			return err
+		}
+	}
+	if v, ok := lookupEnv(EnvHTTPTimeout); ok {
+		return c.applyHTTPTimeout(v, EnvHTTPTimeout)
+	}
+	return nil
+}
+
+func (c *Config) applyTimeout(v string, name string) error {
+	if v == "" {
+		return nil
+	}
+	d, err := parseTimeout(v, name)
+	if err != nil {
+		return err
+	}
+	c.WithTimeout(d)
+	return nil
+}
+
+func (c *Config) applyHTTPTimeout(v string, name string) error {
+	if v == "" {
+		return nil
+	}
+	d, err := parseTimeout(v, name)
+	if err != nil {
+		return err
+	}
+	c.WithHTTPClient(&http.Client{Timeout: d})
+	return nil
+}
+
+func parseTimeout(v string, name string) (time.Duration, error) {
+	d, err := time.ParseDuration(v)
+	if err != nil || d < 0 {
+		return 0, loadError(fmt.Errorf("invalid %s %q: must be a duration like 30s", name, v))
+	}
+	return d, nil
+}
+
+func loadError(err error) error {
+	return apierror.ErrInvalidConfig.Record(fmt.Errorf("failed loading config: %w", err))
+}
@@ -0,0 +1,150 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nirdosh17/go-sdk-template/apierror"
+	"github.com/nirdosh17/go-sdk-template/client"
+	"github.com/nirdosh17/go-sdk-template/credentials"
+	"github.com/nirdosh17/go-sdk-template/test"
+)
+
+const yamlConfig = `
+profiles:
+  default:
+    endpoint: https://api.chatai.com
+    maxRetries: 5
+  staging:
+    apiKey: staging-key
+    endpoint: https://staging.chatai.com
+    debug: true
+    timeout: 30s
+    httpTimeout: 10s
+`
+
+const jsonConfig = `{"profiles": {"default": {"endpoint": "https://json.chatai.com", "maxRetries": 2}}}`
+
+// env returns an environment variable lookup reading from the given map only.
+func env(vars map[string]string) LoadOption {
+	return WithEnvLookup(func(key string) (string, bool) {
+		v, ok := vars[key]
+		return v, ok
+	})
+}
+
+func writeConfigFile(t *testing.T, name, content string) string {
+	filename := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(filename, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return filename
+}
+
+func TestLoadDefaultConfig_defaults(t *testing.T) {
+	c, err := LoadDefaultConfig(WithConfigFile(writeConfigFile(t, "config", "profiles: {}")), env(nil))
+	test.ExpectNil(t, "LoadDefaultConfig", err)
+	test.ExpectEqual(t, "Endpoint", apiBasePath, c.Endpoint)
+	test.ExpectEqual(t, "Debug", false, c.Debug)
+	test.ExpectNotNil(t, "Credentials", c.Credentials)
+}
+
+func TestLoadDefaultConfig_file(t *testing.T) {
+	filename := writeConfigFile(t, "config.yaml", yamlConfig)
+
+	c, err := LoadDefaultConfig(WithConfigFile(filename), env(nil))
+	test.ExpectNil(t, "LoadDefaultConfig", err)
+	test.ExpectEqual(t, "Endpoint", "https://api.chatai.com", c.Endpoint)
+	test.ExpectEqual(t, "MaxRetries", 5, c.MaxRetries)
+
+	c, err = LoadDefaultConfig(WithConfigFile(filename), WithProfile("staging"), env(nil))
+	test.ExpectNil(t, "LoadDefaultConfig staging", err)
+	test.ExpectEqual(t, "APIKey", "staging-key", c.APIKey)
+	test.ExpectNil(t, "Credentials", c.Credentials)
+	test.ExpectEqual(t, "Endpoint", "https://staging.chatai.com", c.Endpoint)
+	test.ExpectEqual(t, "Debug", true, c.Debug)
+	test.ExpectEqual(t, "Timeout", 30*time.Second, c.Timeout)
+	test.ExpectEqual(t, "HTTP timeout", 10*time.Second, c.HTTPClient.(*http.Client).Timeout)
+}
+
+func TestLoadDefaultConfig_jsonFile(t *testing.T) {
+	filename := writeConfigFile(t, "config.json", jsonConfig)
+
+	c, err := LoadDefaultConfig(env(map[string]string{EnvConfigFile: filename}))
+	test.ExpectNil(t, "LoadDefaultConfig", err)
+	test.ExpectEqual(t, "Endpoint", "https://json.chatai.com", c.Endpoint)
+	test.ExpectEqual(t, "MaxRetries", 2, c.MaxRetries)
+}
+
+func TestLoadDefaultConfig_env(t *testing.T) {
+	filename := writeConfigFile(t, "config.yaml", yamlConfig)
+
+	c, err := LoadDefaultConfig(WithConfigFile(filename), env(map[string]string{
+		EnvProfile:     "staging",
+		EnvAPIKey:      "env-key",
+		EnvEndpoint:    "https://env.chatai.com",
+		EnvMaxRetries:  "7",
+		EnvDebug:       "false",
+		EnvTimeout:     "1m",
+		EnvHTTPTimeout: "5s",
+	}))
+	test.ExpectNil(t, "LoadDefaultConfig", err)
+	test.ExpectEqual(t, "APIKey", "env-key", c.APIKey)
+	test.ExpectEqual(t, "Endpoint", "https://env.chatai.com", c.Endpoint)
+	test.ExpectEqual(t, "MaxRetries", 7, c.MaxRetries)
+	test.ExpectEqual(t, "Retryer MaxRetries", 7, c.Retryer.(*client.Retry).MaxRetries)
+	test.ExpectEqual(t, "Debug", false, c.Debug)
+	test.ExpectEqual(t, "Timeout", time.Minute, c.Timeout)
+	test.ExpectEqual(t, "HTTP timeout", 5*time.Second, c.HTTPClient.(*http.Client).Timeout)
+
+	// explicit settings take precedence
+	c.WithEndpoint("https://explicit.chatai.com")
+	test.ExpectEqual(t, "explicit Endpoint", "https://explicit.chatai.com", c.Endpoint)
+}
+
+func TestLoadDefaultConfig_errors(t *testing.T) {
+	filename := writeConfigFile(t, "config.yaml", yamlConfig)
+
+	tests := map[string][]LoadOption{
+		"missing file":        {WithConfigFile(filepath.Join(t.TempDir(), "missing.yaml")), env(nil)},
+		"invalid file":        {WithConfigFile(writeConfigFile(t, "config.json", "profiles:")), env(nil)},
+		"missing profile":     {WithConfigFile(filename), WithProfile("production"), env(nil)},
+		"invalid max retries": {WithConfigFile(filename), env(map[string]string{EnvMaxRetries: "many"})},
+		"invalid debug":       {WithConfigFile(filename), env(map[string]string{EnvDebug: "yes please"})},
+		"invalid timeout":     {WithConfigFile(filename), env(map[string]string{EnvTimeout: "30"})},
+	}
+	for name, opts := range tests {
+		c, err := LoadDefaultConfig(opts...)
+		test.ExpectEqual(t, name, true, errors.Is(err, &apierror.ErrInvalidConfig))
+		test.ExpectEqual(t, name+" config", true, c == nil)
+	}
+}
+
+func TestLoadDefaultConfig_notValidated(t *testing.T) {
+	filename := writeConfigFile(t, "config.yaml", yamlConfig)
+
+	c, err := LoadDefaultConfig(WithConfigFile(filename), env(map[string]string{EnvEndpoint: "api.chatai.com"}))
+	test.ExpectNil(t, "LoadDefaultConfig", err)
+	test.ExpectEqual(t, "invalid endpoint", true, errors.Is(c.Validate(), &apierror.ErrInvalidConfig))
+
+	c.WithEndpoint("https://explicit.chatai.com")
+	test.ExpectNil(t, "Validate after override", c.Validate())
+}
+
+func TestLoadDefaultConfig_credentialsUseEnvLookup(t *testing.T) {
+	t.Setenv(EnvAPIKey, "os-key")
+	missing := filepath.Join(t.TempDir(), "credentials")
+
+	c, err := LoadDefaultConfig(WithConfigFile(writeConfigFile(t, "config", "profiles: {}")), env(map[string]string{
+		credentials.EnvCredentialsFile: missing,
+	}))
+	test.ExpectNil(t, "LoadDefaultConfig", err)
+
+	_, err = c.Credentials.Retrieve(context.Background())
+	test.ExpectEqual(t, "credentials error", true, errors.Is(err, &apierror.ErrCredentials))
+}
@@ -0,0 +1,83 @@
+package config
+
+import (
+	"time"
+
+	"github.com/nirdosh17/go-sdk-template/client"
+	"github.com/nirdosh17/go-sdk-template/logger"
+)
+
+// Option overrides settings of a config copy. Services accept options to change settings of a single api call
+// without touching the shared config.
+//
+// Example:
+//
+//	ans, err := ai.AskAIWithContext(ctx, "question", config.Timeout(5*time.Second), config.MaxRetries(1))
+type Option func(*Config)
+
+// Copy returns a copy of the config with the given options applied. Changes to the copy do not affect the original config.
+//
+// Retryer is cloned if it implements client.RetryerCloner, otherwise it is shared with the original config.
+// Loggers, credentials, metrics, regional endpoints, rate limiters, circuit breakers and other collaborators are shared as they are safe for concurrent use.
+func (c *Config) Copy(opts ...Option) *Config {
+	cp := *c
+	if r, ok := c.Retryer.(client.RetryerCloner); ok {
+		cp.Retryer = r.Clone()
+	}
+	if c.Interceptors != nil {
+		cp.Interceptors = append([]client.Interceptor{}, c.Interceptors...)
+	}
+	if c.Headers != nil {
+		cp.Headers = c.Headers.Clone()
+	}
+	if c.Redaction != nil {
+		r := *c.Redaction
+		cp.Redaction = &r
+	}
+
+	for _, opt := range opts {
+		opt(&cp)
+	}
+	return &cp
+}
+
+// Timeout limits the duration of the api call including retries. See Config.Timeout.
+func Timeout(d time.Duration) Option {
+	return func(c *Config) {
+		c.WithTimeout(d)
+	}
+}
+
+// MaxRetries overrides max retry count. It is ignored with a warning for custom retryers which do not implement
+// client.RetryerCloner, as changing them would affect every config sharing the retryer.
+func MaxRetries(n int) Option {
+	return func(c *Config) {
+		if _, ok := c.Retryer.(client.RetryerCloner); !ok {
+			logger.Structured(c.Logger).LogLevel(logger.SeverityWarn, "max retries option ignored: retryer does not implement client.RetryerCloner",
+				"max_retries", n)
+			return
+		}
+		c.WithMaxRetries(n)
+	}
+}
+
+// Endpoint overrides the service endpoint.
+func Endpoint(endpoint string) Option {
+	return func(c *Config) {
+		c.WithEndpoint(endpoint)
+	}
+}
+
+// Header adds a header to the requests.
+func Header(key, value string) Option {
+	return func(c *Config) {
+		c.WithHeader(key, value)
+	}
+}
+
+// Debug enables or disables verbose logging.
+func Debug(enabled bool) Option {
+	return func(c *Config) {
+		c.Debug = enabled
+	}
+}
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nirdosh17/go-sdk-template/client"
+	"github.com/nirdosh17/go-sdk-template/logger"
+	"github.com/nirdosh17/go-sdk-template/test"
+)
+
+func TestConfig_Copy(t *testing.T) {
+	noop := client.InterceptorFuncs{}
+	c := NewConfig("apiKey").WithHeader("X-Team", "search").WithInterceptors(noop).WithRedaction(client.DefaultRedaction())
+
+	cp := c.Copy()
+	cp.WithMaxRetries(7).WithHeader("X-Team", "ads").WithInterceptors(noop).WithEndpoint("https://eu.chatai.com")
+	cp.Redaction.MetadataOnly = true
+
+	test.ExpectEqual(t, "copy MaxRetries", 7, cp.Retryer.(*client.Retry).MaxRetries)
+	test.ExpectEqual(t, "original MaxRetries", client.DefaultMaxRetries, c.Retryer.(*client.Retry).MaxRetries)
+	test.ExpectEqual(t, "original headers", 1, len(c.Headers.Values("X-Team")))
+	test.ExpectEqual(t, "copy headers", 2, len(cp.Headers.Values("X-Team")))
+	test.ExpectEqual(t, "original interceptors", 1, len(c.Interceptors))
+	test.ExpectEqual(t, "original endpoint", apiBasePath, c.Endpoint)
+	test.ExpectEqual(t, "original redaction", false, c.Redaction.MetadataOnly)
+}
+
+func TestConfig_Copy_options(t *testing.T) {
+	c := NewConfig("apiKey")
+
+	cp := c.Copy(Timeout(time.Second), MaxRetries(1), Endpoint("https://eu.chatai.com"), Header("X-Team", "search"), Debug(true))
+
+	test.ExpectEqual(t, "Timeout", time.Second, cp.Timeout)
+	test.ExpectEqual(t, "MaxRetries", 1, cp.Retryer.(*client.Retry).MaxRetries)
+	test.ExpectEqual(t, "Endpoint", "https://eu.chatai.com", cp.Endpoint)
+	test.ExpectEqual(t, "Header", "search", cp.Headers.Get("X-Team"))
+	test.ExpectEqual(t, "Debug", true, cp.Debug)
+
+	test.ExpectEqual(t, "original Timeout", time.Duration(0), c.Timeout)
+	test.ExpectEqual(t, "original MaxRetries", client.DefaultMaxRetries, c.Retryer.(*client.Retry).MaxRetries)
+	test.ExpectEqual(t, "original Headers", true, c.Headers == nil)
+	test.ExpectEqual(t, "original Debug", false, c.Debug)
+}
+
+func TestMaxRetries_customRetryer(t *testing.T) {
+	r := &mockRetry{MaxRetries: 2}
+	c := NewConfig("apiKey").WithRetryer(r)
+
+	var logged []string
+	c.WithLogger(logger.LoggerFunc(func(args ...interface{}) { logged = append(logged, fmt.Sprint(args...)) }))
+
+	c.Copy(MaxRetries(5))
+	test.ExpectEqual(t, "shared retryer MaxRetries", 2, r.MaxRetries)
+	test.ExpectEqual(t, "warning logged", 1, len(logged))
+	test.ExpectEqual(t, "warning", true, strings.Contains(logged[0], "max retries option ignored"))
+}
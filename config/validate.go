@@ -0,0 +1,137 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/nirdosh17/go-sdk-template/apierror"
+	"github.com/nirdosh17/go-sdk-template/client"
+)
+
+// FieldError describes a problem with a single config field.
+type FieldError struct {
+	// Field is the name of the config field e.g. Endpoint
+	Field string
+	// Problem describes what is wrong with the field value.
+	Problem string
+}
+
+func (e FieldError) Error() string {
+	return e.Field + ": " + e.Problem
+}
+
+// ValidationError lists all problems found by Config.Validate.
+// It is wrapped in apierror.ErrInvalidConfig and can be extracted with errors.As.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	problems := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		problems[i] = f.Error()
+	}
+	return strings.Join(problems, "; ")
+}
+
+// Validate checks the config and reports all problems at once. Services validate their config before every request.
+//
+// Returned error matches apierror.ErrInvalidConfig. Problems of each field can be inspected with errors.As:
+//
+//	var verr *config.ValidationError
+//	if errors.As(c.Validate(), &verr) {
+//		for _, f := range verr.Fields {
+//			fmt.Println(f.Field, f.Problem)
+//		}
+//	}
+func (c *Config) Validate() error {
+	var fields []FieldError
+	invalid := func(field, problem string) {
+		fields = append(fields, FieldError{Field: field, Problem: problem})
+	}
+
+	if c.APIKey == "" && c.Credentials == nil {
+		invalid("APIKey", "must be set unless a credentials provider is configured")
+	}
+
+	if c.Regions == nil {
+		if problem := validateEndpoint(c.Endpoint); problem != "" {
+			invalid("Endpoint", problem)
+		}
+	} else {
+		if c.Regions.Resolver == nil {
+			invalid("Regions.Resolver", "must not be nil")
+		}
+		if len(c.Regions.Regions) == 0 {
+			invalid("Regions.Regions", "must contain at least one region")
+		}
+		switch r := c.Regions.Resolver.(type) {
+		case client.RegionURLs:
+			for _, region := range c.Regions.Regions {
+				if u, ok := r[region]; ok {
+					if problem := validateEndpoint(u); problem != "" {
+						invalid(fmt.Sprintf("Regions.Resolver[%s]", region), problem)
+					}
+				}
+			}
+		case client.EndpointTemplate:
+			if strings.HasSuffix(string(r), "/") {
+				invalid("Regions.Resolver", fmt.Sprintf("template %q must not end with a slash", r))
+			}
+		}
+	}
+
+	if c.HTTPClient == nil {
+		invalid("HTTPClient", "must not be nil")
+	}
+
+	if c.Retryer == nil {
+		invalid("Retryer", "must not be nil")
+	}
+
+	if c.MaxRetries < 0 {
+		invalid("MaxRetries", fmt.Sprintf("must not be negative, got %d", c.MaxRetries))
+	}
+
+	if c.Timeout < 0 {
+		invalid("Timeout", fmt.Sprintf("must not be negative, got %s", c.Timeout))
+	}
+
+	for i, interceptor := range c.Interceptors {
+		if interceptor == nil {
+			invalid(fmt.Sprintf("Interceptors[%d]", i), "must not be nil")
+		}
+	}
+
+	if len(fields) == 0 {
+		return nil
+	}
+	return apierror.ErrInvalidConfig.Record(&ValidationError{Fields: fields})
+}
+
+// validateEndpoint returns the problem with the endpoint URL, if any.
+func validateEndpoint(endpoint string) string {
+	if endpoint == "" {
+		return "must be set"
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Sprintf("invalid URL %q", endpoint)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Sprintf("URL %q must use http or https scheme", endpoint)
+	}
+	if u.Host == "" {
+		return fmt.Sprintf("URL %q has no host", endpoint)
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return fmt.Sprintf("URL %q must not contain query or fragment", endpoint)
+	}
+	// service paths are joined with a slash
+	if strings.HasSuffix(u.Path, "/") {
+		return fmt.Sprintf("URL %q must not end with a slash", endpoint)
+	}
+	return ""
+}
@@ -0,0 +1,75 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/nirdosh17/go-sdk-template/apierror"
+	"github.com/nirdosh17/go-sdk-template/client"
+	"github.com/nirdosh17/go-sdk-template/credentials"
+	"github.com/nirdosh17/go-sdk-template/test"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	test.ExpectNil(t, "NewConfig", NewConfig("apiKey").Validate())
+	test.ExpectNil(t, "NewConfig with credentials", NewConfig("").WithCredentials(credentials.StaticProvider{APIKey: "apiKey"}).Validate())
+	test.ExpectNil(t, "https endpoint", NewConfig("apiKey").WithEndpoint("https://api.chatai.com/v1").Validate())
+}
+
+func TestConfig_Validate_missingAPIKey(t *testing.T) {
+	err := NewConfig("").Validate()
+	test.ExpectEqual(t, "invalid config error", true, errors.Is(err, &apierror.ErrInvalidConfig))
+	test.ExpectEqual(t, "message", true, strings.Contains(err.Error(), "APIKey"))
+}
+
+func TestConfig_Validate_reportsAllFields(t *testing.T) {
+	c := &Config{Endpoint: "api.chatai.com", MaxRetries: -1, Interceptors: []client.Interceptor{nil}}
+
+	err := c.Validate()
+	test.ExpectEqual(t, "invalid config error", true, errors.Is(err, &apierror.ErrInvalidConfig))
+
+	var verr *ValidationError
+	test.ExpectEqual(t, "validation error", true, errors.As(err, &verr))
+
+	fields := make([]string, len(verr.Fields))
+	for i, f := range verr.Fields {
+		fields[i] = f.Field
+	}
+	test.ExpectEqual(t, "fields", "APIKey,Endpoint,HTTPClient,Retryer,MaxRetries,Interceptors[0]", strings.Join(fields, ","))
+	test.ExpectEqual(t, "message", true, strings.Contains(err.Error(), "HTTPClient: must not be nil; Retryer: must not be nil"))
+}
+
+func TestConfig_Validate_endpoint(t *testing.T) {
+	endpoints := []string{"", "api.chatai.com", "ftp://api.chatai.com", "http://", "https://api.chatai.com?region=eu", "http://%zz", "https://api.chatai.com/"}
+	for _, e := range endpoints {
+		err := NewConfig("apiKey").WithEndpoint(e).Validate()
+
+		var verr *ValidationError
+		test.ExpectEqual(t, "validation error for "+e, true, errors.As(err, &verr))
+		test.ExpectEqual(t, "invalid field for "+e, "Endpoint", verr.Fields[0].Field)
+	}
+}
+
+func TestConfig_Validate_regions(t *testing.T) {
+	c := NewConfig("apiKey").WithEndpoint("").WithRegions(nil)
+
+	var verr *ValidationError
+	test.ExpectEqual(t, "validation error", true, errors.As(c.Validate(), &verr))
+	test.ExpectEqual(t, "fields", 2, len(verr.Fields))
+	test.ExpectEqual(t, "resolver", "Regions.Resolver", verr.Fields[0].Field)
+	test.ExpectEqual(t, "regions", "Regions.Regions", verr.Fields[1].Field)
+}
+
+func TestConfig_Validate_regionsTrailingSlash(t *testing.T) {
+	c := NewConfig("apiKey").WithRegions(client.RegionURLs{"eu": "https://eu.chatai.com/", "us": "https://us.chatai.com"}, "eu", "us")
+
+	var verr *ValidationError
+	test.ExpectEqual(t, "validation error", true, errors.As(c.Validate(), &verr))
+	test.ExpectEqual(t, "fields", 1, len(verr.Fields))
+	test.ExpectEqual(t, "region URL", "Regions.Resolver[eu]", verr.Fields[0].Field)
+
+	c.WithRegions(client.EndpointTemplate("https://{region}.chatai.com/"), "eu")
+	test.ExpectEqual(t, "template", true, errors.As(c.Validate(), &verr))
+	test.ExpectEqual(t, "template field", "Regions.Resolver", verr.Fields[0].Field)
+}
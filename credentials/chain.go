@@ -0,0 +1,47 @@
+package credentials
+
+import (
+	"context"
+	"errors"
+
+	"github.com/nirdosh17/go-sdk-template/apierror"
+)
+
+// ChainProvider tries the providers in order and returns credentials of the first one which succeeds.
+type ChainProvider struct {
+	Providers []Provider
+}
+
+// NewChain returns a provider trying the given providers in order.
+func NewChain(providers ...Provider) ChainProvider {
+	return ChainProvider{Providers: providers}
+}
+
+// NewDefaultChain returns a cached chain of the environment variable CHATAI_API_KEY followed by
+// the shared credentials file.
+func NewDefaultChain() *Cache {
+	return NewCache(NewChain(EnvProvider{}, FileProvider{}))
+}
+
+// Retrieve returns credentials of the first provider which succeeds. Errors of all providers are returned if none succeeds.
+func (c ChainProvider) Retrieve(ctx context.Context) (Credentials, error) {
+	var errs []error
+	for _, p := range c.Providers {
+		creds, err := p.Retrieve(ctx)
+		if err == nil {
+			return creds, nil
+		}
+		errs = append(errs, err)
+
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	if len(errs) == 0 {
+		errs = append(errs, errors.New("no credential providers"))
+	}
+	return Credentials{}, apierror.ErrCredentials.Record(errors.Join(errs...))
+}
+
+// to enforce compile type check
+var _ Provider = ChainProvider{}
@@ -0,0 +1,37 @@
+package credentials
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/nirdosh17/go-sdk-template/apierror"
+	"github.com/nirdosh17/go-sdk-template/test"
+)
+
+func TestChainProvider_Retrieve(t *testing.T) {
+	failing := ProviderFunc(func(ctx context.Context) (Credentials, error) {
+		return Credentials{}, errors.New("unavailable")
+	})
+	chain := NewChain(failing, StaticProvider{APIKey: "static-key"}, StaticProvider{APIKey: "unused"})
+
+	creds, err := chain.Retrieve(context.Background())
+	test.ExpectNil(t, "Retrieve", err)
+	test.ExpectEqual(t, "APIKey", "static-key", creds.APIKey)
+}
+
+func TestChainProvider_Retrieve_allFail(t *testing.T) {
+	t.Setenv("TEST_CHATAI_KEY", "")
+	failing := ProviderFunc(func(ctx context.Context) (Credentials, error) {
+		return Credentials{}, errors.New("unavailable")
+	})
+
+	_, err := NewChain(EnvProvider{Variable: "TEST_CHATAI_KEY"}, failing).Retrieve(context.Background())
+	test.ExpectEqual(t, "credentials error", true, errors.Is(err, &apierror.ErrCredentials))
+	test.ExpectEqual(t, "env error", true, strings.Contains(err.Error(), "TEST_CHATAI_KEY is not set"))
+	test.ExpectEqual(t, "func error", true, strings.Contains(err.Error(), "unavailable"))
+
+	_, err = NewChain().Retrieve(context.Background())
+	test.ExpectEqual(t, "empty chain", true, errors.Is(err, &apierror.ErrCredentials))
+}
@@ -0,0 +1,106 @@
+// Package credentials provides API keys to the sdk from static values, environment variables, shared credentials file
+// or an external command. Providers can be chained and cached, so keys can be rotated without restarting the application.
+package credentials
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultExpiryWindow refreshes cached credentials this long before they expire.
+	DefaultExpiryWindow = 10 * time.Second
+)
+
+// Credentials used to authenticate requests.
+type Credentials struct {
+	APIKey string
+	// Expires is the time after which the credentials must be retrieved again. Credentials never expire if zero.
+	Expires time.Time
+	// Source is the name of the provider which retrieved the credentials.
+	Source string
+}
+
+// Expired reports whether the credentials expire before the given time.
+func (c Credentials) Expired(t time.Time) bool {
+	return !c.Expires.IsZero() && !t.Before(c.Expires)
+}
+
+// Provider retrieves credentials. Implementations must be safe for concurrent use.
+type Provider interface {
+	Retrieve(ctx context.Context) (Credentials, error)
+}
+
+// Invalidator is implemented by providers caching credentials. Invalidate discards cached credentials,
+// e.g. after they are rejected by the server.
+type Invalidator interface {
+	Invalidate()
+}
+
+// ProviderFunc is an adapter to use ordinary functions as Provider.
+type ProviderFunc func(ctx context.Context) (Credentials, error)
+
+// Retrieve calls f(ctx).
+func (f ProviderFunc) Retrieve(ctx context.Context) (Credentials, error) {
+	return f(ctx)
+}
+
+// StaticProvider returns the same API key on every call.
+type StaticProvider struct {
+	APIKey string
+}
+
+// Retrieve returns the static API key.
+func (p StaticProvider) Retrieve(ctx context.Context) (Credentials, error) {
+	return Credentials{APIKey: p.APIKey, Source: "static"}, nil
+}
+
+// Cache wraps a provider and returns cached credentials until they expire.
+// Concurrent calls wait for a single retrieval when the credentials are refreshed.
+type Cache struct {
+	Provider Provider
+	// ExpiryWindow refreshes the credentials this long before they expire.
+	ExpiryWindow time.Duration
+
+	mu     sync.Mutex
+	creds  Credentials
+	cached bool
+}
+
+// NewCache returns a cache of credentials retrieved by the given provider.
+func NewCache(p Provider) *Cache {
+	return &Cache{Provider: p, ExpiryWindow: DefaultExpiryWindow}
+}
+
+// Retrieve returns cached credentials, retrieving them from the provider if they are missing or expired.
+func (c *Cache) Retrieve(ctx context.Context) (Credentials, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.cached && !c.creds.Expired(time.Now().Add(c.ExpiryWindow)) {
+		return c.creds, nil
+	}
+
+	creds, err := c.Provider.Retrieve(ctx)
+	if err != nil {
+		return Credentials{}, err
+	}
+	c.creds, c.cached = creds, true
+	return creds, nil
+}
+
+// Invalidate discards cached credentials. Next call to Retrieve retrieves them from the provider.
+func (c *Cache) Invalidate() {
+	c.mu.Lock()
+	c.cached = false
+	c.mu.Unlock()
+}
+
+// to enforce compile type check
+var (
+	_ Provider    = ProviderFunc(nil)
+	_ Provider    = StaticProvider{}
+	_ Provider    = (*Cache)(nil)
+	_ Invalidator = (*Cache)(nil)
+)
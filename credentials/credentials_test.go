@@ -0,0 +1,85 @@
+package credentials
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nirdosh17/go-sdk-template/test"
+)
+
+func TestCredentials_Expired(t *testing.T) {
+	now := time.Now()
+	test.ExpectEqual(t, "never expires", false, Credentials{}.Expired(now))
+	test.ExpectEqual(t, "before expiry", false, Credentials{Expires: now.Add(time.Second)}.Expired(now))
+	test.ExpectEqual(t, "at expiry", true, Credentials{Expires: now}.Expired(now))
+}
+
+func TestCache_Retrieve(t *testing.T) {
+	calls := 0
+	c := NewCache(ProviderFunc(func(ctx context.Context) (Credentials, error) {
+		calls++
+		return Credentials{APIKey: "key"}, nil
+	}))
+
+	for i := 0; i < 3; i++ {
+		creds, err := c.Retrieve(context.Background())
+		test.ExpectNil(t, "Retrieve", err)
+		test.ExpectEqual(t, "APIKey", "key", creds.APIKey)
+	}
+	test.ExpectEqual(t, "provider calls", 1, calls)
+
+	c.Invalidate()
+	c.Retrieve(context.Background())
+	test.ExpectEqual(t, "provider calls after Invalidate", 2, calls)
+}
+
+func TestCache_Retrieve_refreshesExpired(t *testing.T) {
+	calls := 0
+	c := NewCache(ProviderFunc(func(ctx context.Context) (Credentials, error) {
+		calls++
+		// expires within the expiry window
+		return Credentials{APIKey: "key", Expires: time.Now().Add(time.Second)}, nil
+	}))
+
+	c.Retrieve(context.Background())
+	c.Retrieve(context.Background())
+	test.ExpectEqual(t, "provider calls", 2, calls)
+}
+
+func TestCache_Retrieve_doesNotCacheErrors(t *testing.T) {
+	calls := 0
+	c := NewCache(ProviderFunc(func(ctx context.Context) (Credentials, error) {
+		calls++
+		return Credentials{}, errors.New("unavailable")
+	}))
+
+	_, err := c.Retrieve(context.Background())
+	test.ExpectNotNil(t, "Retrieve", err)
+	c.Retrieve(context.Background())
+	test.ExpectEqual(t, "provider calls", 2, calls)
+}
+
+func TestCache_Retrieve_concurrent(t *testing.T) {
+	var mu sync.Mutex
+	calls := 0
+	c := NewCache(ProviderFunc(func(ctx context.Context) (Credentials, error) {
+		mu.Lock()
+		calls++
+		mu.Unlock()
+		return Credentials{APIKey: "key"}, nil
+	}))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.Retrieve(context.Background())
+		}()
+	}
+	wg.Wait()
+	test.ExpectEqual(t, "provider calls", 1, calls)
+}
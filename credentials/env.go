@@ -0,0 +1,48 @@
+package credentials
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/nirdosh17/go-sdk-template/apierror"
+)
+
+const (
+	// EnvAPIKey is the environment variable read by EnvProvider by default.
+	EnvAPIKey = "CHATAI_API_KEY"
+)
+
+// EnvProvider reads the API key from an environment variable on every call.
+type EnvProvider struct {
+	// Variable is the name of the environment variable. Defaults to CHATAI_API_KEY.
+	Variable string
+	// LookupEnv reads the environment variable. Defaults to os.LookupEnv.
+	LookupEnv func(string) (string, bool)
+}
+
+// Retrieve returns the API key set in the environment variable.
+func (p EnvProvider) Retrieve(ctx context.Context) (Credentials, error) {
+	name := p.Variable
+	if name == "" {
+		name = EnvAPIKey
+	}
+
+	key := getenv(p.LookupEnv, name)
+	if key == "" {
+		return Credentials{}, apierror.ErrCredentials.Record(fmt.Errorf("environment variable %s is not set", name))
+	}
+	return Credentials{APIKey: key, Source: "env"}, nil
+}
+
+// getenv returns the value of the environment variable read by lookupEnv, or by os.LookupEnv if lookupEnv is nil.
+func getenv(lookupEnv func(string) (string, bool), name string) string {
+	if lookupEnv == nil {
+		lookupEnv = os.LookupEnv
+	}
+	v, _ := lookupEnv(name)
+	return v
+}
+
+// to enforce compile type check
+var _ Provider = EnvProvider{}
@@ -0,0 +1,40 @@
+package credentials
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/nirdosh17/go-sdk-template/apierror"
+	"github.com/nirdosh17/go-sdk-template/test"
+)
+
+func TestEnvProvider_Retrieve(t *testing.T) {
+	t.Setenv(EnvAPIKey, "env-key")
+
+	creds, err := EnvProvider{}.Retrieve(context.Background())
+	test.ExpectNil(t, "Retrieve", err)
+	test.ExpectEqual(t, "APIKey", "env-key", creds.APIKey)
+	test.ExpectEqual(t, "Source", "env", creds.Source)
+}
+
+func TestEnvProvider_Retrieve_notSet(t *testing.T) {
+	t.Setenv("TEST_CHATAI_KEY", "")
+
+	_, err := EnvProvider{Variable: "TEST_CHATAI_KEY"}.Retrieve(context.Background())
+	test.ExpectEqual(t, "credentials error", true, errors.Is(err, &apierror.ErrCredentials))
+}
+
+func TestEnvProvider_Retrieve_lookupEnv(t *testing.T) {
+	t.Setenv(EnvAPIKey, "env-key")
+	lookup := func(key string) (string, bool) {
+		if key == EnvAPIKey {
+			return "lookup-key", true
+		}
+		return "", false
+	}
+
+	creds, err := EnvProvider{LookupEnv: lookup}.Retrieve(context.Background())
+	test.ExpectNil(t, "Retrieve", err)
+	test.ExpectEqual(t, "APIKey", "lookup-key", creds.APIKey)
+}
@@ -0,0 +1,128 @@
+package credentials
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nirdosh17/go-sdk-template/apierror"
+)
+
+const (
+	// EnvCredentialsFile overrides the location of the shared credentials file.
+	EnvCredentialsFile = "CHATAI_SHARED_CREDENTIALS_FILE"
+	// EnvProfile selects the profile of the shared credentials file.
+	EnvProfile = "CHATAI_PROFILE"
+	// DefaultProfile is used if no profile is selected.
+	DefaultProfile = "default"
+)
+
+// FileProvider reads the API key of a named profile from a shared credentials file.
+// The file is read on every call, so wrap the provider in a Cache to avoid reading it for each request.
+//
+// The file uses INI format. A profile contains either a static api_key or a credential_process
+// command run by ProcessProvider:
+//
+//	[default]
+//	api_key = my-api-key
+//
+//	[production]
+//	credential_process = /usr/local/bin/chatai-credentials --env production
+type FileProvider struct {
+	// Filename of the credentials file. Defaults to CHATAI_SHARED_CREDENTIALS_FILE or ~/.chatai/credentials.
+	Filename string
+	// Profile to read. Defaults to CHATAI_PROFILE or "default".
+	Profile string
+	// LookupEnv reads CHATAI_SHARED_CREDENTIALS_FILE and CHATAI_PROFILE. Defaults to os.LookupEnv.
+	LookupEnv func(string) (string, bool)
+}
+
+// Retrieve returns credentials of the profile.
+func (p FileProvider) Retrieve(ctx context.Context) (Credentials, error) {
+	filename, err := p.filename()
+	if err != nil {
+		return Credentials{}, apierror.ErrCredentials.Record(err)
+	}
+	profile := p.profile()
+
+	profiles, err := readProfiles(filename)
+	if err != nil {
+		return Credentials{}, apierror.ErrCredentials.Record(fmt.Errorf("failed reading credentials file: %w", err))
+	}
+	values, ok := profiles[profile]
+	if !ok {
+		return Credentials{}, apierror.ErrCredentials.Record(fmt.Errorf("profile %q not found in %s", profile, filename))
+	}
+
+	if key := values["api_key"]; key != "" {
+		return Credentials{APIKey: key, Source: "file"}, nil
+	}
+	if command := values["credential_process"]; command != "" {
+		return ProcessProvider{Command: command}.Retrieve(ctx)
+	}
+	return Credentials{}, apierror.ErrCredentials.Record(fmt.Errorf("profile %q has no api_key or credential_process", profile))
+}
+
+func (p FileProvider) filename() (string, error) {
+	if p.Filename != "" {
+		return p.Filename, nil
+	}
+	if f := getenv(p.LookupEnv, EnvCredentialsFile); f != "" {
+		return f, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed locating credentials file: %w", err)
+	}
+	return filepath.Join(home, ".chatai", "credentials"), nil
+}
+
+func (p FileProvider) profile() string {
+	if p.Profile != "" {
+		return p.Profile
+	}
+	if profile := getenv(p.LookupEnv, EnvProfile); profile != "" {
+		return profile
+	}
+	return DefaultProfile
+}
+
+// readProfiles parses key-value pairs of each profile in an INI file. Lines starting with # or ; are comments.
+func readProfiles(filename string) (map[string]map[string]string, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	profiles := map[string]map[string]string{}
+	var current map[string]string
+
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			name := strings.TrimSpace(line[1 : len(line)-1])
+			current = map[string]string{}
+			profiles[name] = current
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok || current == nil {
+			return nil, fmt.Errorf("invalid line %d", n)
+		}
+		current[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return profiles, scanner.Err()
+}
+
+// to enforce compile type check
+var _ Provider = FileProvider{}
@@ -0,0 +1,77 @@
+package credentials
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nirdosh17/go-sdk-template/apierror"
+	"github.com/nirdosh17/go-sdk-template/test"
+)
+
+const credentialsFile = `
+# shared credentials
+[default]
+api_key = default-key
+
+[production]
+api_key=production-key
+
+; key from external command
+[process]
+credential_process = echo {"apiKey":"process-key"}
+
+[empty]
+`
+
+func writeCredentialsFile(t *testing.T) string {
+	filename := filepath.Join(t.TempDir(), "credentials")
+	if err := os.WriteFile(filename, []byte(credentialsFile), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return filename
+}
+
+func TestFileProvider_Retrieve(t *testing.T) {
+	filename := writeCredentialsFile(t)
+	t.Setenv(EnvProfile, "")
+
+	tests := []struct {
+		profile string
+		key     string
+	}{
+		{"", "default-key"},
+		{"production", "production-key"},
+		{"process", "process-key"},
+	}
+	for _, tt := range tests {
+		creds, err := FileProvider{Filename: filename, Profile: tt.profile}.Retrieve(context.Background())
+		test.ExpectNil(t, "Retrieve "+tt.profile, err)
+		test.ExpectEqual(t, "APIKey of "+tt.profile, tt.key, creds.APIKey)
+	}
+}
+
+func TestFileProvider_Retrieve_fromEnv(t *testing.T) {
+	t.Setenv(EnvCredentialsFile, writeCredentialsFile(t))
+	t.Setenv(EnvProfile, "production")
+
+	creds, err := FileProvider{}.Retrieve(context.Background())
+	test.ExpectNil(t, "Retrieve", err)
+	test.ExpectEqual(t, "APIKey", "production-key", creds.APIKey)
+}
+
+func TestFileProvider_Retrieve_errors(t *testing.T) {
+	filename := writeCredentialsFile(t)
+
+	providers := map[string]FileProvider{
+		"missing file":    {Filename: filepath.Join(t.TempDir(), "missing")},
+		"missing profile": {Filename: filename, Profile: "staging"},
+		"empty profile":   {Filename: filename, Profile: "empty"},
+	}
+	for name, p := range providers {
+		_, err := p.Retrieve(context.Background())
+		test.ExpectEqual(t, name, true, errors.Is(err, &apierror.ErrCredentials))
+	}
+}
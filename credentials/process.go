@@ -0,0 +1,76 @@
+package credentials
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/nirdosh17/go-sdk-template/apierror"
+)
+
+const (
+	// DefaultProcessTimeout stops the credential command if it does not finish within this period.
+	DefaultProcessTimeout = time.Minute
+)
+
+// ProcessProvider runs an external command which prints credentials as JSON to stdout:
+//
+//	{"version": 1, "apiKey": "my-api-key", "expiration": "2024-01-02T15:04:05Z"}
+//
+// Expiration is optional and formatted as RFC 3339. Wrap the provider in a Cache to run the command only
+// once the credentials expire.
+type ProcessProvider struct {
+	// Command is split on white space into the program and its arguments. Quoting is not supported.
+	Command string
+	// Timeout of the command. Defaults to DefaultProcessTimeout.
+	Timeout time.Duration
+}
+
+// processOutput is the JSON printed by the credential command.
+type processOutput struct {
+	Version    int       `json:"version"`
+	APIKey     string    `json:"apiKey"`
+	Expiration time.Time `json:"expiration"`
+}
+
+// Retrieve runs the command and returns the credentials it prints.
+func (p ProcessProvider) Retrieve(ctx context.Context) (Credentials, error) {
+	args := strings.Fields(p.Command)
+	if len(args) == 0 {
+		return Credentials{}, apierror.ErrCredentials.Record(fmt.Errorf("credential process command is empty"))
+	}
+
+	timeout := p.Timeout
+	if timeout <= 0 {
+		timeout = DefaultProcessTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return Credentials{}, apierror.ErrCredentials.Record(fmt.Errorf("credential process failed: %w: %s", err, strings.TrimSpace(stderr.String())))
+	}
+
+	var out processOutput
+	if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+		return Credentials{}, apierror.ErrCredentials.Record(fmt.Errorf("invalid credential process output: %w", err))
+	}
+	if out.Version > 1 {
+		return Credentials{}, apierror.ErrCredentials.Record(fmt.Errorf("unsupported credential process output version %d", out.Version))
+	}
+	if out.APIKey == "" {
+		return Credentials{}, apierror.ErrCredentials.Record(fmt.Errorf("credential process returned no apiKey"))
+	}
+	return Credentials{APIKey: out.APIKey, Expires: out.Expiration, Source: "process"}, nil
+}
+
+// to enforce compile type check
+var _ Provider = ProcessProvider{}
@@ -0,0 +1,35 @@
+package credentials
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nirdosh17/go-sdk-template/apierror"
+	"github.com/nirdosh17/go-sdk-template/test"
+)
+
+func TestProcessProvider_Retrieve(t *testing.T) {
+	p := ProcessProvider{Command: `echo {"version":1,"apiKey":"process-key","expiration":"2030-01-02T15:04:05Z"}`}
+
+	creds, err := p.Retrieve(context.Background())
+	test.ExpectNil(t, "Retrieve", err)
+	test.ExpectEqual(t, "APIKey", "process-key", creds.APIKey)
+	test.ExpectEqual(t, "Expires", true, creds.Expires.Equal(time.Date(2030, 1, 2, 15, 4, 5, 0, time.UTC)))
+	test.ExpectEqual(t, "Source", "process", creds.Source)
+}
+
+func TestProcessProvider_Retrieve_errors(t *testing.T) {
+	commands := map[string]string{
+		"empty command":       "",
+		"failing command":     "false",
+		"invalid output":      "echo not-json",
+		"missing key":         `echo {"version":1}`,
+		"unsupported version": `echo {"version":2,"apiKey":"key"}`,
+	}
+	for name, command := range commands {
+		_, err := ProcessProvider{Command: command}.Retrieve(context.Background())
+		test.ExpectEqual(t, name, true, errors.Is(err, &apierror.ErrCredentials))
+	}
+}
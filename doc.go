@@ -2,26 +2,59 @@
 //
 // Features offered by the SDK:
 //
+// # Credentials
+//
+// API key can be passed to `config.NewConfig` or retrieved by a credentials provider. `config.LoadDefaultConfig` reads it from
+// the environment variable CHATAI_API_KEY or the shared credentials file ~/.chatai/credentials. Providers for environment
+// variables, credentials file profiles and external commands can be chained with `config.WithCredentials`.
+//
 // # HTTP Client
 //
 // Default HTTP client can be overridden by own client from config. This enables us to have fine grained control over our requests. For example, using a proxy server.
 //
 // # Retry Logic
 //
-// The sdk has a default retry algorithm which is based on a fixed window delay. We can overwrite this behavior by passing our own retry function which satisfies the given Retryer interface.
+// The sdk has a default retry algorithm which is based on a fixed window delay. Exponential backoff with jitter can be enabled with `config.WithExponentialBackoff()`. We can overwrite this behavior by passing our own retry function which satisfies the given Retryer interface.
 //
 // Default max retry count can also be overridden if needed.
 //
+// Retried calls are sent with the same Idempotency-Key header, so the server does not answer a question twice when a response is lost.
+// The key can be chosen with `client.ContextWithIdempotencyKey(ctx, key)`.
+//
+// # Rate limiting
+//
+// `config.WithRateLimit(rps, burst)` delays requests to stay under a request rate shared by all services. The rate is lowered
+// temporarily when the server throttles requests.
+//
+// # Circuit breaker
+//
+// `config.WithCircuitBreaker(client.NewCircuitBreaker())` fails calls fast with apierror.ErrCircuitOpen while the failure rate
+// of requests is too high. Trial requests are let through after a cooldown to check whether the server has recovered.
+//
 // # Use of context
 //
 // Timeouts and cancellations can be handled by passing `context`. e.g. service.AskAIWithContext(ctx, ...)
 //
+// # Async jobs
+//
+// Long questions can be submitted as jobs with `SubmitQuestion` which returns a job ID right away. `GetJob` fetches the state
+// of the job and `WaitForJob` polls it with backoff until it finishes or the context is done. `client.Waiter` polls any resource.
+//
+// Instead of polling, job callbacks can be received with `webhook.NewHandler(secret, onEvent)`. It verifies the signature and
+// timestamp of each callback and rejects replayed events.
+//
 // # Logging
 //
 // We can enable debug mode for verbose logging. When debug is enabled, it prints out http requets and response objects.
+// Credentials and user questions are redacted from the dumps. Redaction rules can be changed with `config.WithRedaction(client.Redaction{...})`
 //
 // We can also override default logger with our own. `config.WithLogger(logger)`
 //
+// Loggers implementing logger.StructuredLogger receive leveled entries with key-value fields. `logger.NewSlogLogger` adapts a `log/slog` logger.
+//
+// Requests are sent with an X-Request-ID header which is logged in debug mode. Pass the ID of the request being served with
+// `client.ContextWithRequestID(ctx, id)` to correlate logs. The ID reported by the server is set on answers and errors.
+//
 // # Override default service endpoint
 //
 // In some cases, we might want to a different API endpoint offered by the service. For example AWS has region based endpoints. We can override the endpoint using
@@ -29,4 +62,13 @@
 // Example:
 //
 // config.WithEndpoint()
+//
+// Multiple regional endpoints can be configured with `config.WithRegions()`. Requests are sent to the fastest healthy region
+// and fail over to the next region on connection errors and 5xx server errors.
+//
+// # Environment and config files
+//
+// `config.LoadDefaultConfig()` builds the config from environment variables CHATAI_API_KEY, CHATAI_ENDPOINT, CHATAI_MAX_RETRIES,
+// CHATAI_DEBUG, CHATAI_TIMEOUT and CHATAI_HTTP_TIMEOUT, and from profiles of a JSON or YAML config file selected with
+// `config.WithConfigFile()` and `config.WithProfile()`.
 package go_sdk_template
@@ -0,0 +1,16 @@
+package logger
+
+import "context"
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying the logger. Retryers use it to log retry attempts.
+func NewContext(ctx context.Context, l Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, l)
+}
+
+// FromContext returns the logger carried by ctx as StructuredLogger. Entries are discarded if ctx has no logger.
+func FromContext(ctx context.Context) StructuredLogger {
+	l, _ := ctx.Value(contextKey{}).(Logger)
+	return Structured(l)
+}
@@ -1,23 +1,61 @@
 // Package logger provides the logging interface.
 //
-// Default logger can be overridden with a custom logger in the Config object.
+// Default logger can be overridden with a custom logger in the Config object. Loggers which also implement
+// StructuredLogger receive leveled entries with key-value fields, e.g. see NewSlogLogger. Plain loggers keep working
+// and receive entries formatted as text.
 package logger
 
 import (
+	"fmt"
 	"log"
 	"os"
+	"strings"
 )
 
 const (
-	LevelInfo  = "INFO:"
+	// Deprecated: use SeverityInfo with StructuredLogger.
+	LevelInfo = "INFO:"
+	// Deprecated: use SeverityError with StructuredLogger.
 	LevelError = "ERROR:"
 )
 
+// Severity is the level of a structured log entry.
+type Severity int
+
+const (
+	SeverityDebug Severity = iota
+	SeverityInfo
+	SeverityWarn
+	SeverityError
+)
+
+// String returns the severity name e.g. INFO
+func (s Severity) String() string {
+	switch s {
+	case SeverityDebug:
+		return "DEBUG"
+	case SeverityInfo:
+		return "INFO"
+	case SeverityWarn:
+		return "WARN"
+	case SeverityError:
+		return "ERROR"
+	default:
+		return fmt.Sprintf("SEVERITY(%d)", int(s))
+	}
+}
+
 // Logger is default log writer for the sdk. Custom loggers must satisfy this interface
 type Logger interface {
 	Log(...interface{})
 }
 
+// StructuredLogger writes leveled log entries with key-value fields e.g. "service", "chatai", "attempt", 2.
+// Loggers passed in the config can optionally implement it to receive structured entries.
+type StructuredLogger interface {
+	LogLevel(level Severity, msg string, keyvals ...interface{})
+}
+
 // useful for the consumers to provide a logger function
 type LoggerFunc func(...interface{})
 
@@ -29,7 +67,8 @@ func (l LoggerFunc) Log(args ...interface{}) {
 // DefaultLogger is a minimal logger
 type SimpleLogger struct {
 	Logger *log.Logger
-	Debug  bool
+	// Debug enables entries below SeverityError
+	Debug bool
 }
 
 // NewDefaultLogger returns a minimal logger
@@ -44,3 +83,91 @@ func NewDefaultLogger() *SimpleLogger {
 func (l *SimpleLogger) Log(args ...interface{}) {
 	l.Logger.Println(args...)
 }
+
+// LogLevel writes the entry as text e.g. "WARN: retrying request service=chatai attempt=2".
+// Entries below SeverityError are skipped unless Debug is enabled. The sdk enables it in debug mode, see Verbose.
+func (l *SimpleLogger) LogLevel(level Severity, msg string, keyvals ...interface{}) {
+	if level < SeverityError && !l.Debug {
+		return
+	}
+	l.Logger.Println(format(level, msg, keyvals))
+}
+
+// Verbose returns a copy of l writing entries of every level if l is a SimpleLogger. Other loggers are returned as is,
+// as they filter entries on their own. It is used in debug mode of the config.
+func Verbose(l Logger) Logger {
+	if sl, ok := l.(*SimpleLogger); ok && !sl.Debug {
+		cp := *sl
+		cp.Debug = true
+		return &cp
+	}
+	return l
+}
+
+// Structured returns the logger as StructuredLogger. Loggers which only implement Logger receive text entries.
+// It returns a logger which discards all entries if l is nil.
+func Structured(l Logger) StructuredLogger {
+	switch sl := l.(type) {
+	case nil:
+		return nopLogger{}
+	case StructuredLogger:
+		return sl
+	default:
+		return textLogger{l}
+	}
+}
+
+// textLogger adapts plain loggers e.g. LoggerFunc to StructuredLogger.
+type textLogger struct {
+	Logger
+}
+
+func (l textLogger) LogLevel(level Severity, msg string, keyvals ...interface{}) {
+	l.Log(format(level, msg, keyvals))
+}
+
+type nopLogger struct{}
+
+func (nopLogger) LogLevel(Severity, string, ...interface{}) {}
+
+// format converts the entry to text e.g. "INFO: request sent service=chatai status=200"
+func format(level Severity, msg string, keyvals []interface{}) string {
+	var b strings.Builder
+	b.WriteString(level.String())
+	b.WriteString(": ")
+	b.WriteString(msg)
+
+	for i := 0; i < len(keyvals); i += 2 {
+		b.WriteString(" ")
+		if i+1 == len(keyvals) {
+			// odd number of arguments, value is missing
+			fmt.Fprintf(&b, "%v=<missing>", keyvals[i])
+			break
+		}
+		fmt.Fprintf(&b, "%v=%v", keyvals[i], keyvals[i+1])
+	}
+	return b.String()
+}
+
+// to enforce compile type check
+var (
+	_ Logger           = (*SimpleLogger)(nil)
+	_ StructuredLogger = (*SimpleLogger)(nil)
+)
+
+// With returns a logger which adds the given key-value fields to every structured entry written by l.
+func With(l Logger, keyvals ...interface{}) Logger {
+	return fieldsLogger{Logger: l, base: Structured(l), keyvals: keyvals}
+}
+
+type fieldsLogger struct {
+	Logger
+	base    StructuredLogger
+	keyvals []interface{}
+}
+
+func (l fieldsLogger) LogLevel(level Severity, msg string, keyvals ...interface{}) {
+	all := make([]interface{}, 0, len(l.keyvals)+len(keyvals))
+	all = append(append(all, l.keyvals...), keyvals...)
+	l.base.LogLevel(level, msg, all...)
+}
@@ -0,0 +1,95 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"testing"
+
+	"github.com/nirdosh17/go-sdk-template/test"
+)
+
+func TestSimpleLogger_LogLevel(t *testing.T) {
+	var buf bytes.Buffer
+	l := &SimpleLogger{Logger: log.New(&buf, "", 0)}
+
+	l.LogLevel(SeverityWarn, "retrying request", "service", "chatai", "attempt", 2)
+	test.ExpectEqual(t, "warn entry without debug", "", buf.String())
+
+	l.LogLevel(SeverityError, "request failed", "status", 500)
+	test.ExpectEqual(t, "error entry", "ERROR: request failed status=500\n", buf.String())
+
+	l.Debug = true
+	buf.Reset()
+	l.LogLevel(SeverityWarn, "retrying request", "service", "chatai", "attempt", 2)
+	test.ExpectEqual(t, "warn entry", "WARN: retrying request service=chatai attempt=2\n", buf.String())
+
+	buf.Reset()
+	l.LogLevel(SeverityDebug, "sending request", "service")
+	test.ExpectEqual(t, "debug entry with missing value", "DEBUG: sending request service=<missing>\n", buf.String())
+}
+
+func TestVerbose(t *testing.T) {
+	l := NewDefaultLogger()
+	v := Verbose(l)
+	test.ExpectEqual(t, "verbose copy", true, v.(*SimpleLogger).Debug)
+	test.ExpectEqual(t, "original", false, l.Debug)
+
+	f := LoggerFunc(func(args ...interface{}) {})
+	test.ExpectSameType(t, "other loggers", f, Verbose(f))
+}
+
+func TestLevel_constants(t *testing.T) {
+	// plain loggers receive the level prefixes as arguments of Log
+	var received string
+	LoggerFunc(func(args ...interface{}) { received = fmt.Sprint(args...) }).Log(LevelError, " request failed")
+	test.ExpectEqual(t, "entry", "ERROR: request failed", received)
+	test.ExpectEqual(t, "LevelInfo", "INFO:", LevelInfo)
+}
+
+func TestStructured(t *testing.T) {
+	t.Run("LoggerFunc", func(t *testing.T) {
+		var received string
+		l := LoggerFunc(func(args ...interface{}) {
+			received = fmt.Sprint(args...)
+		})
+
+		Structured(l).LogLevel(SeverityError, "request failed", "status", 500)
+		test.ExpectEqual(t, "LoggerFunc entry", "ERROR: request failed status=500", received)
+	})
+
+	t.Run("StructuredLogger", func(t *testing.T) {
+		l := NewDefaultLogger()
+		test.ExpectEqual(t, "Structured", StructuredLogger(l), Structured(l))
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		// must not panic
+		Structured(nil).LogLevel(SeverityInfo, "discarded")
+	})
+}
+
+func TestWith(t *testing.T) {
+	var received string
+	l := LoggerFunc(func(args ...interface{}) {
+		received = fmt.Sprint(args...)
+	})
+
+	With(l, "service", "chatai").(StructuredLogger).LogLevel(SeverityInfo, "request sent", "status", 200)
+	test.ExpectEqual(t, "entry with fields", "INFO: request sent service=chatai status=200", received)
+}
+
+func TestFromContext(t *testing.T) {
+	var received string
+	l := LoggerFunc(func(args ...interface{}) {
+		received = fmt.Sprint(args...)
+	})
+
+	FromContext(context.Background()).LogLevel(SeverityInfo, "discarded")
+	test.ExpectEqual(t, "entry without logger", "", received)
+
+	ctx := NewContext(context.Background(), l)
+	FromContext(ctx).LogLevel(SeverityInfo, "request sent")
+	test.ExpectEqual(t, "entry", "INFO: request sent", received)
+}
@@ -0,0 +1,53 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+// SlogLogger adapts a *slog.Logger to both Logger and StructuredLogger.
+//
+// Example:
+//
+//	l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+//	c := config.NewConfig("apiKey").WithLogger(logger.NewSlogLogger(l))
+type SlogLogger struct {
+	Logger *slog.Logger
+}
+
+// NewSlogLogger returns an adapter for the given slog logger. slog.Default() is used if l is nil.
+func NewSlogLogger(l *slog.Logger) *SlogLogger {
+	if l == nil {
+		l = slog.Default()
+	}
+	return &SlogLogger{Logger: l}
+}
+
+// Log writes the arguments as a message of level info.
+func (l *SlogLogger) Log(args ...interface{}) {
+	l.Logger.Info(fmt.Sprint(args...))
+}
+
+func (l *SlogLogger) LogLevel(level Severity, msg string, keyvals ...interface{}) {
+	l.Logger.Log(context.Background(), slogLevel(level), msg, keyvals...)
+}
+
+func slogLevel(s Severity) slog.Level {
+	switch s {
+	case SeverityDebug:
+		return slog.LevelDebug
+	case SeverityWarn:
+		return slog.LevelWarn
+	case SeverityError:
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// to enforce compile type check
+var (
+	_ Logger           = (*SlogLogger)(nil)
+	_ StructuredLogger = (*SlogLogger)(nil)
+)
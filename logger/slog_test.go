@@ -0,0 +1,31 @@
+package logger
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/nirdosh17/go-sdk-template/test"
+)
+
+func TestSlogLogger(t *testing.T) {
+	var buf bytes.Buffer
+	handler := slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		// drop time to keep the output stable
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})
+	l := NewSlogLogger(slog.New(handler))
+
+	l.LogLevel(SeverityWarn, "retrying request", "service", "chatai", "attempt", 2)
+	test.ExpectEqual(t, "structured entry", "level=WARN msg=\"retrying request\" service=chatai attempt=2\n", buf.String())
+
+	buf.Reset()
+	l.Log("plain", "message")
+	test.ExpectEqual(t, "plain entry", "level=INFO msg=plainmessage\n", buf.String())
+}
@@ -0,0 +1,117 @@
+package metrics
+
+import (
+	"encoding/json"
+	"expvar"
+	"strconv"
+	"sync"
+)
+
+// ExpvarCollector publishes sdk metrics with expvar so that they are served on /debug/vars.
+//
+// Published map contains:
+//
+//	requests         number of requests by "service:status:errCode"
+//	retries          number of retries by service
+//	throttles        number of throttled requests by service
+//	latency_seconds  latency histogram by service
+type ExpvarCollector struct {
+	requests  *expvar.Map
+	retries   *expvar.Map
+	throttles *expvar.Map
+	latency   *expvar.Map
+
+	mu         sync.Mutex
+	histograms map[string]*histogram
+}
+
+// NewExpvarCollector publishes the metrics under the given name. It panics if the name is already in use, like expvar.Publish.
+//
+// Example:
+//
+//	c := config.NewConfig("apiKey").WithMetrics(metrics.NewExpvarCollector("chatai_sdk"))
+func NewExpvarCollector(name string) *ExpvarCollector {
+	c := &ExpvarCollector{
+		requests:   new(expvar.Map),
+		retries:    new(expvar.Map),
+		throttles:  new(expvar.Map),
+		latency:    new(expvar.Map),
+		histograms: map[string]*histogram{},
+	}
+
+	m := expvar.NewMap(name)
+	m.Set("requests", c.requests)
+	m.Set("retries", c.retries)
+	m.Set("throttles", c.throttles)
+	m.Set("latency_seconds", c.latency)
+	return c
+}
+
+func (c *ExpvarCollector) ObserveRequest(r Request) {
+	c.requests.Add(r.Service+":"+strconv.Itoa(r.StatusCode)+":"+r.ErrCode, 1)
+
+	c.mu.Lock()
+	h, ok := c.histograms[r.Service]
+	if !ok {
+		h = newHistogram(DefaultLatencyBuckets)
+		c.histograms[r.Service] = h
+		c.latency.Set(r.Service, h)
+	}
+	c.mu.Unlock()
+
+	h.observe(r.Latency.Seconds())
+}
+
+func (c *ExpvarCollector) ObserveRetry(service string, attempt int) {
+	c.retries.Add(service, 1)
+}
+
+func (c *ExpvarCollector) ObserveThrottle(service string) {
+	c.throttles.Add(service, 1)
+}
+
+// histogram is a cumulative histogram which satisfies expvar.Var.
+type histogram struct {
+	mu      sync.Mutex
+	buckets []float64
+	counts  []uint64
+	count   uint64
+	sum     float64
+}
+
+func newHistogram(buckets []float64) *histogram {
+	return &histogram{buckets: buckets, counts: make([]uint64, len(buckets))}
+}
+
+func (h *histogram) observe(v float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for i, b := range h.buckets {
+		if v <= b {
+			h.counts[i]++
+		}
+	}
+	h.count++
+	h.sum += v
+}
+
+// String returns the histogram as JSON e.g. {"buckets":{"0.5":2,"1":3},"count":3,"sum":1.2}
+func (h *histogram) String() string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	buckets := make(map[string]uint64, len(h.buckets))
+	for i, b := range h.buckets {
+		buckets[strconv.FormatFloat(b, 'g', -1, 64)] = h.counts[i]
+	}
+	b, _ := json.Marshal(struct {
+		Buckets map[string]uint64 `json:"buckets"`
+		Count   uint64            `json:"count"`
+		Sum     float64           `json:"sum"`
+	}{buckets, h.count, h.sum})
+	return string(b)
+}
+
+// to enforce compile type check
+var _ Collector = (*ExpvarCollector)(nil)
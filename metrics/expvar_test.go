@@ -0,0 +1,39 @@
+package metrics
+
+import (
+	"encoding/json"
+	"expvar"
+	"testing"
+	"time"
+
+	"github.com/nirdosh17/go-sdk-template/test"
+)
+
+func TestExpvarCollector(t *testing.T) {
+	c := NewExpvarCollector("expvar_collector_test")
+
+	c.ObserveRequest(Request{Service: "chatai", StatusCode: 200, Latency: 80 * time.Millisecond, Attempt: 1})
+	c.ObserveRequest(Request{Service: "chatai", StatusCode: 429, ErrCode: "TOO_MANY_REQUESTS", Latency: 2 * time.Second, Attempt: 1})
+	c.ObserveRetry("chatai", 2)
+	c.ObserveThrottle("chatai")
+
+	var published struct {
+		Requests  map[string]int `json:"requests"`
+		Retries   map[string]int `json:"retries"`
+		Throttles map[string]int `json:"throttles"`
+		Latency   map[string]struct {
+			Buckets map[string]int `json:"buckets"`
+			Count   int            `json:"count"`
+		} `json:"latency_seconds"`
+	}
+	err := json.Unmarshal([]byte(expvar.Get("expvar_collector_test").String()), &published)
+	test.ExpectNil(t, "json.Unmarshal", err)
+
+	test.ExpectEqual(t, "requests chatai:200:", 1, published.Requests["chatai:200:"])
+	test.ExpectEqual(t, "requests chatai:429:TOO_MANY_REQUESTS", 1, published.Requests["chatai:429:TOO_MANY_REQUESTS"])
+	test.ExpectEqual(t, "retries", 1, published.Retries["chatai"])
+	test.ExpectEqual(t, "throttles", 1, published.Throttles["chatai"])
+	test.ExpectEqual(t, "latency count", 2, published.Latency["chatai"].Count)
+	test.ExpectEqual(t, "latency bucket 0.1", 1, published.Latency["chatai"].Buckets["0.1"])
+	test.ExpectEqual(t, "latency bucket 2.5", 2, published.Latency["chatai"].Buckets["2.5"])
+}
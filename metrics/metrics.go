@@ -0,0 +1,64 @@
+// Package metrics provides the interface to collect request, retry and latency metrics from the sdk.
+//
+// The package has no dependencies. Adapters are provided for expvar in this package and for Prometheus in metrics/prometheus.
+// Custom collectors can be passed in the Config object.
+package metrics
+
+import "time"
+
+// Collector receives measurements from the sdk. Implementations must be safe for concurrent use.
+type Collector interface {
+	// ObserveRequest is called once for every request sent to the server, including retried ones.
+	ObserveRequest(r Request)
+	// ObserveRetry is called once per retry of a call. "attempt" starts from 2 for the first retry.
+	ObserveRetry(service string, attempt int)
+	// ObserveThrottle is called when the server has throttled a request.
+	ObserveThrottle(service string)
+}
+
+// Request describes the outcome of a single request sent to the server.
+type Request struct {
+	// Service is the name of the service e.g. chatai
+	Service string
+	// Method is the HTTP method of the request
+	Method string
+	// StatusCode is the HTTP status code of the response. Zero if no response was received.
+	StatusCode int
+	// ErrCode is the apierror code of the failure. Empty if the request succeeded.
+	ErrCode string
+	// Latency is the time taken to receive and decode the response.
+	Latency time.Duration
+	// Attempt is the attempt number of the request starting from 1.
+	Attempt int
+}
+
+// DefaultLatencyBuckets are upper bounds in seconds of the latency histograms used by the adapters.
+var DefaultLatencyBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+// CollectorFuncs allows to implement only the required methods of a Collector. Nil functions are skipped.
+type CollectorFuncs struct {
+	ObserveRequestFunc  func(r Request)
+	ObserveRetryFunc    func(service string, attempt int)
+	ObserveThrottleFunc func(service string)
+}
+
+func (f CollectorFuncs) ObserveRequest(r Request) {
+	if f.ObserveRequestFunc != nil {
+		f.ObserveRequestFunc(r)
+	}
+}
+
+func (f CollectorFuncs) ObserveRetry(service string, attempt int) {
+	if f.ObserveRetryFunc != nil {
+		f.ObserveRetryFunc(service, attempt)
+	}
+}
+
+func (f CollectorFuncs) ObserveThrottle(service string) {
+	if f.ObserveThrottleFunc != nil {
+		f.ObserveThrottleFunc(service)
+	}
+}
+
+// to enforce compile type check
+var _ Collector = CollectorFuncs{}
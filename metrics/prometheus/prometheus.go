@@ -0,0 +1,93 @@
+// Package prometheus provides a metrics.Collector which exports sdk metrics to Prometheus.
+package prometheus
+
+import (
+	"strconv"
+
+	"github.com/nirdosh17/go-sdk-template/metrics"
+	prom "github.com/prometheus/client_golang/prometheus"
+)
+
+// DefaultNamespace is the prefix of all metric names unless overridden.
+const DefaultNamespace = "sdk"
+
+// Collector records sdk metrics in Prometheus counters and histograms:
+//
+//	<namespace>_requests_total{service,status,code}
+//	<namespace>_request_duration_seconds{service}
+//	<namespace>_retries_total{service}
+//	<namespace>_throttled_requests_total{service}
+type Collector struct {
+	requests  *prom.CounterVec
+	latency   *prom.HistogramVec
+	retries   *prom.CounterVec
+	throttles *prom.CounterVec
+}
+
+// NewCollector creates the metrics and registers them with the given registerer.
+// Namespace defaults to DefaultNamespace if empty. If registration fails, metrics registered so far are unregistered,
+// so that it can be retried with the same registerer.
+//
+// Example:
+//
+//	collector, err := prometheus.NewCollector(prom.DefaultRegisterer, "chatai_sdk")
+//	if err != nil {
+//		// handle err
+//	}
+//	c := config.NewConfig("apiKey").WithMetrics(collector)
+func NewCollector(reg prom.Registerer, namespace string) (*Collector, error) {
+	if namespace == "" {
+		namespace = DefaultNamespace
+	}
+
+	c := &Collector{
+		requests: prom.NewCounterVec(prom.CounterOpts{
+			Namespace: namespace,
+			Name:      "requests_total",
+			Help:      "Number of requests sent to the server by service, HTTP status and error code.",
+		}, []string{"service", "status", "code"}),
+		latency: prom.NewHistogramVec(prom.HistogramOpts{
+			Namespace: namespace,
+			Name:      "request_duration_seconds",
+			Help:      "Latency of requests sent to the server.",
+			Buckets:   metrics.DefaultLatencyBuckets,
+		}, []string{"service"}),
+		retries: prom.NewCounterVec(prom.CounterOpts{
+			Namespace: namespace,
+			Name:      "retries_total",
+			Help:      "Number of retried requests.",
+		}, []string{"service"}),
+		throttles: prom.NewCounterVec(prom.CounterOpts{
+			Namespace: namespace,
+			Name:      "throttled_requests_total",
+			Help:      "Number of requests throttled by the server.",
+		}, []string{"service"}),
+	}
+
+	cols := []prom.Collector{c.requests, c.latency, c.retries, c.throttles}
+	for i, col := range cols {
+		if err := reg.Register(col); err != nil {
+			for _, registered := range cols[:i] {
+				reg.Unregister(registered)
+			}
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+func (c *Collector) ObserveRequest(r metrics.Request) {
+	c.requests.WithLabelValues(r.Service, strconv.Itoa(r.StatusCode), r.ErrCode).Inc()
+	c.latency.WithLabelValues(r.Service).Observe(r.Latency.Seconds())
+}
+
+func (c *Collector) ObserveRetry(service string, attempt int) {
+	c.retries.WithLabelValues(service).Inc()
+}
+
+func (c *Collector) ObserveThrottle(service string) {
+	c.throttles.WithLabelValues(service).Inc()
+}
+
+// to enforce compile type check
+var _ metrics.Collector = (*Collector)(nil)
@@ -0,0 +1,62 @@
+package prometheus
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nirdosh17/go-sdk-template/metrics"
+	"github.com/nirdosh17/go-sdk-template/test"
+	prom "github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestCollector(t *testing.T) {
+	reg := prom.NewRegistry()
+	c, err := NewCollector(reg, "")
+	test.ExpectNil(t, "NewCollector", err)
+
+	c.ObserveRequest(metrics.Request{Service: "chatai", StatusCode: 200, Latency: 80 * time.Millisecond, Attempt: 1})
+	c.ObserveRequest(metrics.Request{Service: "chatai", StatusCode: 503, ErrCode: "INTERNAL_SERVER_ERROR", Latency: time.Second, Attempt: 1})
+	c.ObserveRetry("chatai", 2)
+	c.ObserveThrottle("chatai")
+
+	expected := `
+# HELP sdk_requests_total Number of requests sent to the server by service, HTTP status and error code.
+# TYPE sdk_requests_total counter
+sdk_requests_total{code="",service="chatai",status="200"} 1
+sdk_requests_total{code="INTERNAL_SERVER_ERROR",service="chatai",status="503"} 1
+# HELP sdk_retries_total Number of retried requests.
+# TYPE sdk_retries_total counter
+sdk_retries_total{service="chatai"} 1
+# HELP sdk_throttled_requests_total Number of requests throttled by the server.
+# TYPE sdk_throttled_requests_total counter
+sdk_throttled_requests_total{service="chatai"} 1
+`
+	err = testutil.GatherAndCompare(reg, strings.NewReader(expected), "sdk_requests_total", "sdk_retries_total", "sdk_throttled_requests_total")
+	test.ExpectNil(t, "GatherAndCompare", err)
+	test.ExpectEqual(t, "histogram series", 1, testutil.CollectAndCount(c.latency))
+}
+
+func TestNewCollector_alreadyRegistered(t *testing.T) {
+	reg := prom.NewRegistry()
+	_, err := NewCollector(reg, "chatai_sdk")
+	test.ExpectNil(t, "first NewCollector", err)
+
+	_, err = NewCollector(reg, "chatai_sdk")
+	test.ExpectNotNil(t, "second NewCollector", err)
+}
+
+func TestNewCollector_registrationFailure(t *testing.T) {
+	reg := prom.NewRegistry()
+	conflict := prom.NewCounterVec(prom.CounterOpts{Namespace: DefaultNamespace, Name: "retries_total", Help: "Number of retried requests."}, []string{"service"})
+	reg.MustRegister(conflict)
+
+	_, err := NewCollector(reg, "")
+	test.ExpectNotNil(t, "NewCollector with conflict", err)
+
+	// metrics registered before the failure are removed, so it can be retried
+	reg.Unregister(conflict)
+	_, err = NewCollector(reg, "")
+	test.ExpectNil(t, "NewCollector retried", err)
+}
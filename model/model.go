@@ -1,7 +1,116 @@
 package model
 
+import "time"
+
+// AskRequestVersion is the version of the AskRequest schema sent to chatai service.
+const AskRequestVersion = 1
+
+// AskRequest is the question sent to chatai service.
+type AskRequest struct {
+	// Version of the request schema. Set to AskRequestVersion by the services.
+	Version int `json:"version"`
+	// Query is the question to answer.
+	Query string `json:"query"`
+	// Model is the name of the model which answers the question. The server picks its default model if empty.
+	Model string `json:"model,omitempty"`
+	// Temperature controls randomness of the answer. The server default is used if nil.
+	Temperature *float64 `json:"temperature,omitempty"`
+	// MaxTokens limits the length of the answer. No limit other than the server default if zero.
+	MaxTokens int `json:"maxTokens,omitempty"`
+	// Metadata is attached to the request as is, e.g. to tag requests of a team.
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// Float64 returns a pointer to v, for optional fields like AskRequest.Temperature.
+func Float64(v float64) *float64 {
+	return &v
+}
+
+// FinishReason tells why the server stopped generating the answer.
+type FinishReason string
+
+const (
+	// FinishReasonStop means the answer is complete.
+	FinishReasonStop FinishReason = "stop"
+	// FinishReasonLength means the answer was cut off by AskRequest.MaxTokens or the server limit.
+	FinishReasonLength FinishReason = "length"
+)
+
+// Usage is the number of tokens consumed by a request.
+type Usage struct {
+	PromptTokens     int `json:"promptTokens"`
+	CompletionTokens int `json:"completionTokens"`
+	TotalTokens      int `json:"totalTokens"`
+}
+
 // AIAnswer is the response from chatai service.
 type AIAnswer struct {
+	// ID of the answer assigned by the server.
+	ID              string  `json:"id,omitempty"`
 	Answer          string  `json:"answer"`
 	ConfidenceScore float32 `json:"confidenceScore"`
+	// ConversationID is set by the server when it keeps track of a conversation. See chatai.Conversation.
+	ConversationID string `json:"conversationId,omitempty"`
+	// Model is the name of the model which answered the question.
+	Model string `json:"model,omitempty"`
+	// FinishReason is only set on complete answers. Chunks of a stream carry it on the last chunk.
+	FinishReason FinishReason `json:"finishReason,omitempty"`
+	// Usage is nil if the server did not report token usage.
+	Usage *Usage `json:"usage,omitempty"`
+	// Created is the Unix time in seconds at which the answer was generated. Zero if unknown.
+	Created int64 `json:"created,omitempty"`
+
+	// Response metadata below is read from response headers and is not part of the body.
+
+	// RequestID identifies the request on the server. It is the ID sent by the client if the server did not report one.
+	// Include it while reporting issues.
+	RequestID string `json:"-"`
+	// Replayed is true if the server returned the stored answer of an earlier attempt with the same idempotency key
+	// instead of answering again.
+	Replayed bool `json:"-"`
+}
+
+// CreatedAt returns the time at which the answer was generated. Zero time if unknown.
+func (a AIAnswer) CreatedAt() time.Time {
+	if a.Created == 0 {
+		return time.Time{}
+	}
+	return time.Unix(a.Created, 0)
+}
+
+// JobStatus is the state of a job.
+type JobStatus string
+
+const (
+	// JobQueued means the job waits to be processed.
+	JobQueued JobStatus = "queued"
+	// JobRunning means the answer is being generated.
+	JobRunning JobStatus = "running"
+	// JobSucceeded means the answer is available in Job.Answer.
+	JobSucceeded JobStatus = "succeeded"
+	// JobFailed means the job has failed, see Job.Error.
+	JobFailed JobStatus = "failed"
+)
+
+// JobError describes why a job has failed.
+type JobError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Job is a question processed asynchronously by chatai service.
+type Job struct {
+	ID     string    `json:"id"`
+	Status JobStatus `json:"status"`
+	// Answer is set once the job has succeeded.
+	Answer *AIAnswer `json:"answer,omitempty"`
+	// Error is set if the job has failed.
+	Error *JobError `json:"error,omitempty"`
+	// Created is the Unix time in seconds at which the job was submitted. Zero if unknown.
+	Created int64 `json:"created,omitempty"`
+}
+
+// Done reports whether the job has reached a final state, i.e. succeeded or failed.
+func (j Job) Done() bool {
+	return j.Status == JobSucceeded || j.Status == JobFailed
 }
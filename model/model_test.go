@@ -0,0 +1,97 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/nirdosh17/go-sdk-template/test"
+)
+
+func TestAskRequest_MarshalJSON(t *testing.T) {
+	req := AskRequest{
+		Version:     AskRequestVersion,
+		Query:       "what is a goroutine?",
+		Model:       "chatai-large",
+		Temperature: Float64(0),
+		MaxTokens:   256,
+		Metadata:    map[string]string{"team": "search"},
+	}
+	b, err := json.Marshal(req)
+	test.ExpectNil(t, "marshal error", err)
+	test.ExpectEqual(t, "wire format",
+		`{"version":1,"query":"what is a goroutine?","model":"chatai-large","temperature":0,"maxTokens":256,"metadata":{"team":"search"}}`,
+		string(b))
+
+	var decoded AskRequest
+	test.ExpectNil(t, "unmarshal error", json.Unmarshal(b, &decoded))
+	test.ExpectEqual(t, "Query", req.Query, decoded.Query)
+	test.ExpectEqual(t, "Temperature", 0.0, *decoded.Temperature)
+	test.ExpectEqual(t, "Metadata", "search", decoded.Metadata["team"])
+
+	again, _ := json.Marshal(decoded)
+	test.ExpectEqual(t, "round trip", string(b), string(again))
+}
+
+func TestAskRequest_MarshalJSON_omitsDefaults(t *testing.T) {
+	b, err := json.Marshal(AskRequest{Version: AskRequestVersion, Query: "hi"})
+	test.ExpectNil(t, "marshal error", err)
+	test.ExpectEqual(t, "wire format", `{"version":1,"query":"hi"}`, string(b))
+}
+
+func TestAIAnswer_UnmarshalJSON(t *testing.T) {
+	wire := `{"id":"ans-1","answer":"a lightweight thread","confidenceScore":0.9,"conversationId":"conv-1","model":"chatai-large","finishReason":"stop","usage":{"promptTokens":5,"completionTokens":4,"totalTokens":9},"created":1700000000}`
+
+	var ans AIAnswer
+	test.ExpectNil(t, "unmarshal error", json.Unmarshal([]byte(wire), &ans))
+	test.ExpectEqual(t, "ID", "ans-1", ans.ID)
+	test.ExpectEqual(t, "ConfidenceScore", float32(0.9), ans.ConfidenceScore)
+	test.ExpectEqual(t, "FinishReason", FinishReasonStop, ans.FinishReason)
+	test.ExpectEqual(t, "Usage", Usage{PromptTokens: 5, CompletionTokens: 4, TotalTokens: 9}, *ans.Usage)
+	test.ExpectEqual(t, "CreatedAt", time.Unix(1700000000, 0), ans.CreatedAt())
+
+	b, err := json.Marshal(ans)
+	test.ExpectNil(t, "marshal error", err)
+	test.ExpectEqual(t, "round trip", wire, string(b))
+}
+
+func TestAIAnswer_MarshalJSON_omitsUnknown(t *testing.T) {
+	b, err := json.Marshal(AIAnswer{Answer: "yes", ConfidenceScore: 80})
+	test.ExpectNil(t, "marshal error", err)
+	test.ExpectEqual(t, "wire format", `{"answer":"yes","confidenceScore":80}`, string(b))
+	test.ExpectEqual(t, "CreatedAt", true, AIAnswer{}.CreatedAt().IsZero())
+}
+
+func TestJob_UnmarshalJSON(t *testing.T) {
+	wire := `{"id":"job-1","status":"succeeded","answer":{"answer":"yes","confidenceScore":80},"created":1700000000}`
+
+	var job Job
+	test.ExpectNil(t, "unmarshal error", json.Unmarshal([]byte(wire), &job))
+	test.ExpectEqual(t, "ID", "job-1", job.ID)
+	test.ExpectEqual(t, "Status", JobSucceeded, job.Status)
+	test.ExpectEqual(t, "Answer", "yes", job.Answer.Answer)
+	test.ExpectEqual(t, "Done", true, job.Done())
+
+	b, err := json.Marshal(job)
+	test.ExpectNil(t, "marshal error", err)
+	test.ExpectEqual(t, "round trip", wire, string(b))
+}
+
+func TestJob_UnmarshalJSON_failed(t *testing.T) {
+	wire := `{"id":"job-1","status":"failed","error":{"code":"MODEL_OVERLOADED","message":"try again later"}}`
+
+	var job Job
+	test.ExpectNil(t, "unmarshal error", json.Unmarshal([]byte(wire), &job))
+	test.ExpectEqual(t, "Error", JobError{Code: "MODEL_OVERLOADED", Message: "try again later"}, *job.Error)
+	test.ExpectEqual(t, "Done", true, job.Done())
+
+	b, err := json.Marshal(job)
+	test.ExpectNil(t, "marshal error", err)
+	test.ExpectEqual(t, "round trip", wire, string(b))
+}
+
+func TestJob_Done(t *testing.T) {
+	test.ExpectEqual(t, "queued", false, Job{Status: JobQueued}.Done())
+	test.ExpectEqual(t, "running", false, Job{Status: JobRunning}.Done())
+	test.ExpectEqual(t, "unknown", false, Job{Status: "paused"}.Done())
+}
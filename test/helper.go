@@ -41,18 +41,45 @@ type MockHTTPClient struct {
 	JSONBody *string
 	// response status code
 	StatusCode int
+	// response headers
+	Header http.Header
 	// returns error if provided
 	Err error
+	// LastRequest is the last request received by the client
+	LastRequest *http.Request
 }
 
 func (c *MockHTTPClient) Do(r *http.Request) (*http.Response, error) {
-	var body io.ReadCloser
+	c.LastRequest = r
+	body := io.NopCloser(bytes.NewReader(nil))
 	if c.JSONBody != nil {
 		body = io.NopCloser(bytes.NewReader([]byte(*c.JSONBody)))
 	}
 
+	header := http.Header{}
+	for k, v := range c.Header {
+		header[k] = v
+	}
+
 	return &http.Response{
 		StatusCode: c.StatusCode,
+		Header:     header,
 		Body:       body,
 	}, c.Err
 }
+
+// HTTPClientFunc is an adapter to use ordinary functions as http client in tests. It satisfies client.HTTPClient interface.
+type HTTPClientFunc func(r *http.Request) (*http.Response, error)
+
+func (f HTTPClientFunc) Do(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+// JSONResponse returns a response with the given status code and JSON body.
+func JSONResponse(statusCode int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: statusCode,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader([]byte(body))),
+	}
+}
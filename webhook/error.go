@@ -0,0 +1,18 @@
+package webhook
+
+import (
+	"errors"
+
+	"github.com/nirdosh17/go-sdk-template/apierror"
+)
+
+var (
+	// ErrInvalidSignature means the signature header is missing, malformed or does not match any of the secrets.
+	ErrInvalidSignature = apierror.New("INVALID_SIGNATURE", errors.New("webhook signature verification failed"))
+	// ErrStaleTimestamp means the signature timestamp is outside the tolerance of the verifier.
+	ErrStaleTimestamp = apierror.New("STALE_TIMESTAMP", errors.New("webhook timestamp outside tolerance"))
+	// ErrReplayedEvent means the event has already been received within the tolerance of the verifier.
+	ErrReplayedEvent = apierror.New("REPLAYED_EVENT", errors.New("webhook event already received"))
+	// ErrInvalidPayload means the body of a correctly signed request is not a valid event.
+	ErrInvalidPayload = apierror.New("INVALID_PAYLOAD", errors.New("invalid webhook payload"))
+)
@@ -0,0 +1,42 @@
+package webhook_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/nirdosh17/go-sdk-template/webhook"
+)
+
+func ExampleNewHandler() {
+	secret := "whsec_example"
+	h, err := webhook.NewHandler(secret, func(ctx context.Context, e webhook.Event) error {
+		switch e.Type {
+		case webhook.EventJobSucceeded:
+			ans, _ := e.Answer()
+			fmt.Println("job", e.Job.ID, "answered:", ans.Answer)
+		case webhook.EventJobFailed:
+			fmt.Println("job", e.Job.ID, "failed:", e.Job.Error.Message)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// callback sent by the server
+	body := []byte(`{"id":"evt-1","type":"job.succeeded","created":1700000000,"job":{"id":"job-1","status":"succeeded","answer":{"answer":"use pprof","confidenceScore":90}}}`)
+	req := httptest.NewRequest(http.MethodPost, "/chatai/webhook", bytes.NewReader(body))
+	req.Header.Set(webhook.SignatureHeader, webhook.Sign(secret, time.Now(), body))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	fmt.Println(rec.Code)
+	// Output:
+	// job job-1 answered: use pprof
+	// 200
+}
@@ -0,0 +1,71 @@
+package webhook
+
+import (
+	"sync"
+	"time"
+)
+
+// pruneInterval is the minimum time between two sweeps of expired events in MemoryReplayStore.
+const pruneInterval = time.Minute
+
+// ReplayStore remembers received events to reject replays. Implementations must be safe for concurrent use.
+// A store shared by all instances of the application, e.g. backed by Redis, protects against replays sent to any instance.
+type ReplayStore interface {
+	// Reserve remembers the event until the given time. It returns false if the event is already remembered.
+	Reserve(eventID string, until time.Time) bool
+	// Release forgets the event.
+	Release(eventID string)
+}
+
+// MemoryReplayStore remembers events in memory of the process. It is safe for concurrent use.
+type MemoryReplayStore struct {
+	mu        sync.Mutex
+	events    map[string]time.Time
+	nextPrune time.Time
+}
+
+// NewMemoryReplayStore returns an empty in-memory store.
+func NewMemoryReplayStore() *MemoryReplayStore {
+	return &MemoryReplayStore{events: map[string]time.Time{}}
+}
+
+// Reserve remembers the event until the given time. It returns false if the event is already remembered.
+func (s *MemoryReplayStore) Reserve(eventID string, until time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.prune(now)
+
+	if expires, ok := s.events[eventID]; ok && now.Before(expires) {
+		return false
+	}
+	if s.events == nil {
+		s.events = map[string]time.Time{}
+	}
+	s.events[eventID] = until
+	return true
+}
+
+// Release forgets the event.
+func (s *MemoryReplayStore) Release(eventID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.events, eventID)
+}
+
+// prune removes expired events at most once per pruneInterval. Caller must hold the lock.
+func (s *MemoryReplayStore) prune(now time.Time) {
+	if now.Before(s.nextPrune) {
+		return
+	}
+	s.nextPrune = now.Add(pruneInterval)
+	for id, expires := range s.events {
+		if !now.Before(expires) {
+			delete(s.events, id)
+		}
+	}
+}
+
+// to enforce compile type check
+var _ ReplayStore = (*MemoryReplayStore)(nil)
@@ -0,0 +1,36 @@
+package webhook
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nirdosh17/go-sdk-template/test"
+)
+
+func TestMemoryReplayStore_Reserve(t *testing.T) {
+	s := NewMemoryReplayStore()
+	until := time.Now().Add(time.Minute)
+
+	test.ExpectEqual(t, "first", true, s.Reserve("evt-1", until))
+	test.ExpectEqual(t, "replay", false, s.Reserve("evt-1", until))
+	test.ExpectEqual(t, "other event", true, s.Reserve("evt-2", until))
+
+	s.Release("evt-1")
+	test.ExpectEqual(t, "after release", true, s.Reserve("evt-1", until))
+}
+
+func TestMemoryReplayStore_Reserve_expired(t *testing.T) {
+	s := NewMemoryReplayStore()
+	s.Reserve("evt-1", time.Now().Add(-time.Second))
+	test.ExpectEqual(t, "expired", true, s.Reserve("evt-1", time.Now().Add(time.Minute)))
+}
+
+func TestMemoryReplayStore_prune(t *testing.T) {
+	s := NewMemoryReplayStore()
+	s.Reserve("evt-1", time.Now().Add(-time.Second))
+	s.Reserve("evt-2", time.Now().Add(time.Minute))
+
+	s.nextPrune = time.Time{}
+	s.Reserve("evt-3", time.Now().Add(time.Minute))
+	test.ExpectEqual(t, "remembered events", 2, len(s.events))
+}
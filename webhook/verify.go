@@ -0,0 +1,208 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nirdosh17/go-sdk-template/apierror"
+)
+
+const (
+	// SignatureHeader carries the timestamp and signatures of a webhook request, e.g. "t=1700000000,v1=5257a8...".
+	// The signature is the hex encoded HMAC-SHA256 of "<timestamp>.<body>" keyed with the shared secret. Multiple v1
+	// signatures are sent while the secret is being rotated.
+	SignatureHeader = "ChatAI-Signature"
+
+	// DefaultTolerance is the maximum age of a webhook request accepted by a Verifier.
+	DefaultTolerance = 5 * time.Minute
+
+	// signatureScheme is the key of signatures in the signature header.
+	signatureScheme = "v1"
+)
+
+// Verifier checks signatures and timestamps of webhook requests and rejects events received before.
+// It is safe for concurrent use.
+type Verifier struct {
+	// Secrets shared with the server. Requests signed with any of them are accepted, so that secrets can be rotated.
+	// Empty secrets are ignored, so a verifier without secrets rejects every request.
+	Secrets []string
+	// Tolerance is the maximum difference between the signature timestamp and the local clock. Defaults to DefaultTolerance.
+	Tolerance time.Duration
+	// ReplayWindow is how long after its signature timestamp an event is remembered. Defaults to Tolerance, which rejects
+	// replays of the same request. Set it longer to also reject events the server redelivers with a fresh signature.
+	ReplayWindow time.Duration
+	// Replays remembers received events. Defaults to an in-memory store, which does not protect against replays sent
+	// to other instances of the application. Use a shared store when running multiple instances.
+	Replays ReplayStore
+
+	// now returns the current time. It is overridden in tests.
+	now func() time.Time
+
+	once           sync.Once
+	defaultReplays ReplayStore
+}
+
+// NewVerifier returns a verifier accepting requests signed with any of the given secrets.
+// It fails with apierror.ErrInvalidConfig if no secret is given or a secret is empty, e.g. read from an unset
+// environment variable, as anyone could sign requests with an empty key.
+func NewVerifier(secrets ...string) (*Verifier, error) {
+	if len(secrets) == 0 {
+		return nil, apierror.ErrInvalidConfig.Record(errors.New("webhook secret missing"))
+	}
+	for i, secret := range secrets {
+		if secret == "" {
+			return nil, apierror.ErrInvalidConfig.Record(fmt.Errorf("webhook secret %d is empty", i))
+		}
+	}
+	return &Verifier{Secrets: secrets, Tolerance: DefaultTolerance, Replays: NewMemoryReplayStore()}, nil
+}
+
+// Verify checks the signature and timestamp of the request and decodes its body. The event is remembered for ReplayWindow
+// after its timestamp, so that a replay of the same event fails with ErrReplayedEvent. Call Release if the event could not
+// be processed, to accept it again when the server redelivers it.
+//
+// Errors match ErrInvalidSignature, ErrStaleTimestamp, ErrReplayedEvent or ErrInvalidPayload.
+func (v *Verifier) Verify(header http.Header, body []byte) (Event, error) {
+	var event Event
+
+	timestamp, signatures, err := parseSignatureHeader(header.Get(SignatureHeader))
+	if err != nil {
+		return event, ErrInvalidSignature.Record(err)
+	}
+	if !v.validSignature(timestamp, body, signatures) {
+		return event, ErrInvalidSignature.Record(ErrInvalidSignature.Err)
+	}
+
+	signedAt := time.Unix(timestamp, 0)
+	if age := v.clock().Sub(signedAt); age > v.tolerance() || age < -v.tolerance() {
+		return event, ErrStaleTimestamp.Record(fmt.Errorf("webhook signed at %s is outside tolerance of %s", signedAt.UTC().Format(time.RFC3339), v.tolerance()))
+	}
+
+	if err := json.Unmarshal(body, &event); err != nil {
+		return event, ErrInvalidPayload.Record(err)
+	}
+	if event.ID == "" {
+		return event, ErrInvalidPayload.Record(errors.New("event ID missing"))
+	}
+
+	// requests are rejected as stale after the tolerance, so events are remembered at least that long
+	if !v.replays().Reserve(event.ID, signedAt.Add(v.replayWindow())) {
+		return event, ErrReplayedEvent.Record(fmt.Errorf("event %s already received", event.ID))
+	}
+	return event, nil
+}
+
+// Release forgets the event, so that it is accepted again when it is redelivered.
+func (v *Verifier) Release(eventID string) {
+	v.replays().Release(eventID)
+}
+
+// Sign returns the signature header value for the body signed with the secret at the given time.
+// It is meant for testing webhook handlers.
+func Sign(secret string, t time.Time, body []byte) string {
+	timestamp := t.Unix()
+	return fmt.Sprintf("t=%d,%s=%s", timestamp, signatureScheme, hex.EncodeToString(signature(secret, timestamp, body)))
+}
+
+func (v *Verifier) validSignature(timestamp int64, body []byte, signatures [][]byte) bool {
+	for _, secret := range v.Secrets {
+		// anyone can sign with an empty key
+		if secret == "" {
+			continue
+		}
+		expected := signature(secret, timestamp, body)
+		for _, s := range signatures {
+			if hmac.Equal(expected, s) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (v *Verifier) replays() ReplayStore {
+	if v.Replays != nil {
+		return v.Replays
+	}
+	v.once.Do(func() { v.defaultReplays = NewMemoryReplayStore() })
+	return v.defaultReplays
+}
+
+func (v *Verifier) tolerance() time.Duration {
+	if v.Tolerance > 0 {
+		return v.Tolerance
+	}
+	return DefaultTolerance
+}
+
+func (v *Verifier) replayWindow() time.Duration {
+	if v.ReplayWindow > v.tolerance() {
+		return v.ReplayWindow
+	}
+	return v.tolerance()
+}
+
+func (v *Verifier) clock() time.Time {
+	if v.now != nil {
+		return v.now()
+	}
+	return time.Now()
+}
+
+// signature returns HMAC-SHA256 of "<timestamp>.<body>".
+func signature(secret string, timestamp int64, body []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return mac.Sum(nil)
+}
+
+// parseSignatureHeader returns the timestamp and the decoded signatures of the header. Unknown schemes are ignored.
+func parseSignatureHeader(value string) (int64, [][]byte, error) {
+	if value == "" {
+		return 0, nil, fmt.Errorf("%s header missing", SignatureHeader)
+	}
+
+	var (
+		timestamp  int64
+		signatures [][]byte
+	)
+	for _, part := range strings.Split(value, ",") {
+		key, val, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			return 0, nil, fmt.Errorf("malformed %s header", SignatureHeader)
+		}
+		switch key {
+		case "t":
+			t, err := strconv.ParseInt(val, 10, 64)
+			if err != nil {
+				return 0, nil, fmt.Errorf("invalid timestamp %q", val)
+			}
+			timestamp = t
+		case signatureScheme:
+			s, err := hex.DecodeString(val)
+			if err != nil {
+				return 0, nil, fmt.Errorf("invalid signature %q", val)
+			}
+			signatures = append(signatures, s)
+		}
+	}
+
+	if timestamp == 0 {
+		return 0, nil, fmt.Errorf("timestamp missing in %s header", SignatureHeader)
+	}
+	if len(signatures) == 0 {
+		return 0, nil, fmt.Errorf("no %s signature in %s header", signatureScheme, SignatureHeader)
+	}
+	return timestamp, signatures, nil
+}
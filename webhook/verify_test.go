@@ -0,0 +1,176 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/nirdosh17/go-sdk-template/apierror"
+	"github.com/nirdosh17/go-sdk-template/model"
+	"github.com/nirdosh17/go-sdk-template/test"
+)
+
+const testSecret = "whsec_test"
+
+var testBody = []byte(`{"id":"evt-1","type":"job.succeeded","created":1700000000,"job":{"id":"job-1","status":"succeeded","answer":{"answer":"yes","confidenceScore":80}}}`)
+
+func mustVerifier(t *testing.T, secrets ...string) *Verifier {
+	t.Helper()
+	v, err := NewVerifier(secrets...)
+	test.ExpectNil(t, "NewVerifier", err)
+	return v
+}
+
+func signedHeader(value string) http.Header {
+	h := http.Header{}
+	h.Set(SignatureHeader, value)
+	return h
+}
+
+func TestSign(t *testing.T) {
+	got := Sign("secret", time.Unix(1700000000, 0), []byte(`{}`))
+	// echo -n '1700000000.{}' | openssl dgst -sha256 -hmac secret
+	test.ExpectEqual(t, "signature header", "t=1700000000,v1=b8569b78799ff9e3cbff0fc2d63a33a2b57f3282abd07c37ae5e8e7d79a5f163", got)
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	v := mustVerifier(t, testSecret)
+	event, err := v.Verify(signedHeader(Sign(testSecret, time.Now(), testBody)), testBody)
+	test.ExpectNil(t, "Verify", err)
+	test.ExpectEqual(t, "ID", "evt-1", event.ID)
+	test.ExpectEqual(t, "Type", EventJobSucceeded, event.Type)
+	test.ExpectEqual(t, "Job.Status", model.JobSucceeded, event.Job.Status)
+
+	ans, ok := event.Answer()
+	test.ExpectEqual(t, "has answer", true, ok)
+	test.ExpectEqual(t, "Answer", "yes", ans.Answer)
+}
+
+func TestVerifier_Verify_rotatedSecret(t *testing.T) {
+	v := mustVerifier(t, "new-secret", testSecret)
+	_, err := v.Verify(signedHeader(Sign(testSecret, time.Now(), testBody)), testBody)
+	test.ExpectNil(t, "old secret", err)
+
+	ts := time.Now().Unix()
+	header := fmt.Sprintf("t=%d,v1=%x,v1=%x", ts, signature("unknown", ts, testBody), signature("new-secret", ts, testBody))
+	_, err = mustVerifier(t, "new-secret").Verify(signedHeader(header), testBody)
+	test.ExpectNil(t, "multiple signatures", err)
+}
+
+func TestVerifier_Verify_invalid(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name   string
+		header string
+		body   []byte
+		want   error
+	}{
+		{"missing header", "", testBody, ErrInvalidSignature},
+		{"malformed header", "garbage", testBody, ErrInvalidSignature},
+		{"missing timestamp", "v1=abcd", testBody, ErrInvalidSignature},
+		{"missing signature", "t=1700000000", testBody, ErrInvalidSignature},
+		{"wrong secret", Sign("other", now, testBody), testBody, ErrInvalidSignature},
+		{"tampered body", Sign(testSecret, now, testBody), []byte(`{"id":"evt-1","type":"job.failed"}`), ErrInvalidSignature},
+		{"old timestamp", Sign(testSecret, now.Add(-DefaultTolerance-time.Minute), testBody), testBody, ErrStaleTimestamp},
+		{"future timestamp", Sign(testSecret, now.Add(DefaultTolerance+time.Minute), testBody), testBody, ErrStaleTimestamp},
+		{"invalid json", Sign(testSecret, now, []byte(`{`)), []byte(`{`), ErrInvalidPayload},
+		{"missing event ID", Sign(testSecret, now, []byte(`{}`)), []byte(`{}`), ErrInvalidPayload},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := mustVerifier(t, testSecret).Verify(signedHeader(tt.header), tt.body)
+			test.ExpectEqual(t, "error "+errString(err), true, errors.Is(err, tt.want))
+		})
+	}
+}
+
+func TestNewVerifier_emptySecret(t *testing.T) {
+	for _, secrets := range [][]string{nil, {""}, {testSecret, ""}} {
+		v, err := NewVerifier(secrets...)
+		test.ExpectEqual(t, fmt.Sprintf("error for %q", secrets), true, errors.Is(err, &apierror.ErrInvalidConfig))
+		test.ExpectEqual(t, "verifier", true, v == nil)
+	}
+}
+
+func TestVerifier_Verify_emptySecret(t *testing.T) {
+	v := &Verifier{Secrets: []string{""}}
+	_, err := v.Verify(signedHeader(Sign("", time.Now(), testBody)), testBody)
+	test.ExpectEqual(t, "signed with empty key", true, errors.Is(err, ErrInvalidSignature))
+}
+
+func TestVerifier_Verify_replay(t *testing.T) {
+	v := mustVerifier(t, testSecret)
+	header := signedHeader(Sign(testSecret, time.Now(), testBody))
+
+	_, err := v.Verify(header, testBody)
+	test.ExpectNil(t, "first delivery", err)
+
+	_, err = v.Verify(header, testBody)
+	test.ExpectEqual(t, "replay", true, errors.Is(err, ErrReplayedEvent))
+
+	v.Release("evt-1")
+	_, err = v.Verify(header, testBody)
+	test.ExpectNil(t, "redelivery after release", err)
+}
+
+// recordingReplayStore remembers until when each event was reserved.
+type recordingReplayStore map[string]time.Time
+
+func (s recordingReplayStore) Reserve(eventID string, until time.Time) bool {
+	s[eventID] = until
+	return true
+}
+
+func (s recordingReplayStore) Release(eventID string) {
+	delete(s, eventID)
+}
+
+func TestVerifier_Verify_replayWindow(t *testing.T) {
+	signedAt := time.Now().Truncate(time.Second)
+	header := signedHeader(Sign(testSecret, signedAt, testBody))
+
+	store := recordingReplayStore{}
+	v := &Verifier{Secrets: []string{testSecret}, Tolerance: time.Minute, Replays: store}
+	v.Verify(header, testBody)
+	test.ExpectEqual(t, "default window", signedAt.Add(time.Minute), store["evt-1"])
+
+	v.ReplayWindow = 24 * time.Hour
+	v.Verify(header, testBody)
+	test.ExpectEqual(t, "replay window", signedAt.Add(24*time.Hour), store["evt-1"])
+
+	v.ReplayWindow = time.Second
+	v.Verify(header, testBody)
+	test.ExpectEqual(t, "window shorter than tolerance", signedAt.Add(time.Minute), store["evt-1"])
+}
+
+func TestVerifier_Verify_zeroValue(t *testing.T) {
+	v := &Verifier{Secrets: []string{testSecret}}
+	header := signedHeader(Sign(testSecret, time.Now(), testBody))
+
+	v.Verify(header, testBody)
+	_, err := v.Verify(header, testBody)
+	test.ExpectEqual(t, "replay", true, errors.Is(err, ErrReplayedEvent))
+}
+
+func TestVerifier_Verify_tolerance(t *testing.T) {
+	signedAt := time.Unix(1700000000, 0)
+	v := &Verifier{Secrets: []string{testSecret}, Tolerance: time.Second}
+	v.now = func() time.Time { return signedAt.Add(2 * time.Second) }
+
+	_, err := v.Verify(signedHeader(Sign(testSecret, signedAt, testBody)), testBody)
+	test.ExpectEqual(t, "stale", true, errors.Is(err, ErrStaleTimestamp))
+
+	v.now = func() time.Time { return signedAt.Add(time.Second) }
+	_, err = v.Verify(signedHeader(Sign(testSecret, signedAt, testBody)), testBody)
+	test.ExpectNil(t, "within tolerance", err)
+}
+
+func errString(err error) string {
+	if err == nil {
+		return "<nil>"
+	}
+	return err.Error()
+}
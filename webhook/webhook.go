@@ -0,0 +1,151 @@
+// Package webhook receives job callbacks of ChatAI service. Requests are verified with an HMAC signature of a secret
+// shared with the server, and replays of earlier requests are rejected, so that callbacks can be trusted instead of polling jobs.
+package webhook
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/nirdosh17/go-sdk-template/logger"
+	"github.com/nirdosh17/go-sdk-template/model"
+)
+
+// DefaultMaxBodyBytes is the largest webhook body accepted by Handler.
+const DefaultMaxBodyBytes = 1 << 20
+
+// EventType is the kind of a webhook event.
+type EventType string
+
+const (
+	// EventJobSucceeded is sent when the answer of a job is available in Event.Job.Answer.
+	EventJobSucceeded EventType = "job.succeeded"
+	// EventJobFailed is sent when a job has failed, see Event.Job.Error.
+	EventJobFailed EventType = "job.failed"
+)
+
+// Event is a callback sent by ChatAI service.
+type Event struct {
+	// ID is unique per event and stays the same when the event is redelivered.
+	ID   string    `json:"id"`
+	Type EventType `json:"type"`
+	// Created is the Unix time in seconds at which the event occurred.
+	Created int64 `json:"created"`
+	// Job is the job the event is about.
+	Job model.Job `json:"job"`
+}
+
+// Answer returns the answer of a succeeded job. It returns false if the event carries no answer.
+func (e Event) Answer() (model.AIAnswer, bool) {
+	if e.Job.Answer == nil {
+		return model.AIAnswer{}, false
+	}
+	return *e.Job.Answer, true
+}
+
+// Handler is an http.Handler which verifies webhook requests and passes their events to OnEvent.
+// Every request fails with 500 Internal Server Error if Verifier is nil.
+//
+// It responds with 401 Unauthorized to requests with invalid signatures or stale timestamps and 400 Bad Request to invalid
+// payloads. Replayed events are acknowledged without calling OnEvent. If OnEvent fails, the handler responds with
+// 500 Internal Server Error and accepts the event again when the server redelivers it.
+type Handler struct {
+	Verifier *Verifier
+	// OnEvent processes a verified event. It is called once per event unless it returns an error or the server
+	// redelivers the event after Verifier.ReplayWindow, which defaults to the signature tolerance.
+	OnEvent func(ctx context.Context, event Event) error
+	// MaxBodyBytes limits the size of the request body. Defaults to DefaultMaxBodyBytes.
+	MaxBodyBytes int64
+	// Logger receives warnings about rejected requests and failed events. Skipped if nil.
+	Logger logger.Logger
+}
+
+// NewHandler returns a handler verifying requests with the shared secret.
+// It fails with apierror.ErrInvalidConfig if the secret is empty.
+//
+// Example:
+//
+//	h, err := webhook.NewHandler(os.Getenv("CHATAI_WEBHOOK_SECRET"), func(ctx context.Context, e webhook.Event) error {
+//		if ans, ok := e.Answer(); ok {
+//			return store.SaveAnswer(ctx, e.Job.ID, ans)
+//		}
+//		return nil
+//	})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	http.Handle("/chatai/webhook", h)
+func NewHandler(secret string, onEvent func(ctx context.Context, event Event) error) (*Handler, error) {
+	v, err := NewVerifier(secret)
+	if err != nil {
+		return nil, err
+	}
+	return &Handler{Verifier: v, OnEvent: onEvent, MaxBodyBytes: DefaultMaxBodyBytes}, nil
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.Verifier == nil {
+		h.warn("webhook handler has no verifier")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	maxBytes := h.MaxBodyBytes
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxBodyBytes
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBytes+1))
+	if err != nil {
+		h.warn("failed reading webhook body", "error", err)
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+	if int64(len(body)) > maxBytes {
+		h.warn("webhook body too large", "limit", maxBytes)
+		http.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	event, err := h.Verifier.Verify(r.Header, body)
+	switch {
+	case errors.Is(err, ErrReplayedEvent):
+		// already processed, acknowledge so that the server stops redelivering it
+		w.WriteHeader(http.StatusOK)
+		return
+	case errors.Is(err, ErrInvalidSignature), errors.Is(err, ErrStaleTimestamp):
+		h.warn("rejected webhook", "error", err)
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	case err != nil:
+		h.warn("rejected webhook", "error", err)
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	if h.OnEvent != nil {
+		if err := h.OnEvent(r.Context(), event); err != nil {
+			h.Verifier.Release(event.ID)
+			h.warn("failed processing webhook event", "event_id", event.ID, "type", event.Type, "error", err)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *Handler) warn(msg string, keyvals ...interface{}) {
+	if h.Logger == nil {
+		return
+	}
+	logger.Structured(h.Logger).LogLevel(logger.SeverityWarn, msg, keyvals...)
+}
+
+// to enforce compile type check
+var _ http.Handler = (*Handler)(nil)
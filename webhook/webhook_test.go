@@ -0,0 +1,114 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nirdosh17/go-sdk-template/apierror"
+	"github.com/nirdosh17/go-sdk-template/logger"
+	"github.com/nirdosh17/go-sdk-template/test"
+)
+
+func deliver(h http.Handler, header string, body []byte) int {
+	req := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(body))
+	if header != "" {
+		req.Header.Set(SignatureHeader, header)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+func mustHandler(t *testing.T, onEvent func(ctx context.Context, e Event) error) *Handler {
+	t.Helper()
+	h, err := NewHandler(testSecret, onEvent)
+	test.ExpectNil(t, "NewHandler", err)
+	return h
+}
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	var received []Event
+	h := mustHandler(t, func(ctx context.Context, e Event) error {
+		received = append(received, e)
+		return nil
+	})
+	header := Sign(testSecret, time.Now(), testBody)
+
+	test.ExpectEqual(t, "status", http.StatusOK, deliver(h, header, testBody))
+	test.ExpectEqual(t, "replay status", http.StatusOK, deliver(h, header, testBody))
+	test.ExpectEqual(t, "events received", 1, len(received))
+	test.ExpectEqual(t, "event ID", "evt-1", received[0].ID)
+}
+
+func TestHandler_ServeHTTP_rejected(t *testing.T) {
+	var entries []string
+	h := mustHandler(t, func(ctx context.Context, e Event) error {
+		t.Error("OnEvent called for rejected webhook")
+		return nil
+	})
+	h.Logger = logger.LoggerFunc(func(args ...interface{}) { entries = append(entries, fmt.Sprint(args...)) })
+
+	now := time.Now()
+	test.ExpectEqual(t, "missing signature", http.StatusUnauthorized, deliver(h, "", testBody))
+	test.ExpectEqual(t, "wrong secret", http.StatusUnauthorized, deliver(h, Sign("other", now, testBody), testBody))
+	test.ExpectEqual(t, "stale", http.StatusUnauthorized, deliver(h, Sign(testSecret, now.Add(-time.Hour), testBody), testBody))
+	test.ExpectEqual(t, "invalid payload", http.StatusBadRequest, deliver(h, Sign(testSecret, now, []byte(`[]`)), []byte(`[]`)))
+
+	test.ExpectEqual(t, "log entries", 4, len(entries))
+	test.ExpectEqual(t, "log entry", true, strings.HasPrefix(entries[0], "WARN: rejected webhook error=INVALID_SIGNATURE"))
+}
+
+func TestHandler_ServeHTTP_method(t *testing.T) {
+	rec := httptest.NewRecorder()
+	mustHandler(t, nil).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/webhook", nil))
+	test.ExpectEqual(t, "status", http.StatusMethodNotAllowed, rec.Code)
+	test.ExpectEqual(t, "Allow", http.MethodPost, rec.Header().Get("Allow"))
+}
+
+func TestHandler_ServeHTTP_bodyLimit(t *testing.T) {
+	h := mustHandler(t, nil)
+	h.MaxBodyBytes = 10
+	test.ExpectEqual(t, "status", http.StatusRequestEntityTooLarge, deliver(h, Sign(testSecret, time.Now(), testBody), testBody))
+}
+
+func TestHandler_ServeHTTP_eventFailure(t *testing.T) {
+	calls := 0
+	h := mustHandler(t, func(ctx context.Context, e Event) error {
+		calls++
+		if calls == 1 {
+			return errors.New("database unavailable")
+		}
+		return nil
+	})
+	header := Sign(testSecret, time.Now(), testBody)
+
+	test.ExpectEqual(t, "failed status", http.StatusInternalServerError, deliver(h, header, testBody))
+	test.ExpectEqual(t, "redelivery status", http.StatusOK, deliver(h, header, testBody))
+	test.ExpectEqual(t, "calls", 2, calls)
+}
+
+func TestNewHandler_emptySecret(t *testing.T) {
+	h, err := NewHandler("", nil)
+	test.ExpectEqual(t, "error", true, errors.Is(err, &apierror.ErrInvalidConfig))
+	test.ExpectEqual(t, "handler", true, h == nil)
+}
+
+func TestHandler_ServeHTTP_noVerifier(t *testing.T) {
+	h := &Handler{OnEvent: func(ctx context.Context, e Event) error {
+		t.Error("OnEvent called without verifier")
+		return nil
+	}}
+	test.ExpectEqual(t, "status", http.StatusInternalServerError, deliver(h, Sign("", time.Now(), testBody), testBody))
+}
+
+func TestEvent_Answer(t *testing.T) {
+	_, ok := Event{Type: EventJobFailed}.Answer()
+	test.ExpectEqual(t, "failed job answer", false, ok)
+}